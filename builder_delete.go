@@ -0,0 +1,183 @@
+package qb
+
+import "strings"
+
+// DeleteLimitGrammar is implemented by grammars
+// which support ORDER BY and LIMIT in DELETE queries
+type DeleteLimitGrammar interface {
+	Grammar
+	DeleteLimit() bool
+}
+
+// DeleteOrderGrammar is implemented by grammars
+// which support ORDER BY in DELETE queries only together with LIMIT
+type DeleteOrderGrammar interface {
+	Grammar
+	DeleteOrderLimit() bool
+}
+
+// DeleteBuilder builds DELETE queries
+//  var w = new(qb.WhereBuilder).Where("id", "=", 1)
+//  var b = new(qb.DeleteBuilder).From("users").Where(w)
+//  _ = b.String() // DELETE FROM "users" WHERE "id" = $1
+//  _ = b.Params() // [1]
+type DeleteBuilder struct {
+	table   string
+	where   *WhereBuilder
+	all     bool
+	order   *OrderBuilder
+	limit   interface{}
+	grammar Grammar
+}
+
+// From sets a table
+func (b *DeleteBuilder) From(table string) *DeleteBuilder {
+	b.table = table
+	return b
+}
+
+// Where sets WHERE expressions
+func (b *DeleteBuilder) Where(where *WhereBuilder) *DeleteBuilder {
+	b.where = where
+	return b
+}
+
+// All allows the query to delete all rows of the table without WHERE expressions
+func (b *DeleteBuilder) All() *DeleteBuilder {
+	b.all = true
+	return b
+}
+
+// OrderBy adds columns to the ORDER BY list in ascending order,
+// it's supported by mysql and sqlite compiled with SQLITE_ENABLE_UPDATE_DELETE_LIMIT
+func (b *DeleteBuilder) OrderBy(columns ...string) *DeleteBuilder {
+	for _, column := range columns {
+		b.orderBuilder().Order(column, "ASC")
+	}
+	return b
+}
+
+// OrderByDesc adds columns to the ORDER BY list in descending order,
+// it's supported by mysql and sqlite compiled with SQLITE_ENABLE_UPDATE_DELETE_LIMIT
+func (b *DeleteBuilder) OrderByDesc(columns ...string) *DeleteBuilder {
+	for _, column := range columns {
+		b.orderBuilder().Order(column, "DESC")
+	}
+	return b
+}
+
+// Order sets ORDER BY expressions,
+// it's supported by mysql and sqlite compiled with SQLITE_ENABLE_UPDATE_DELETE_LIMIT
+func (b *DeleteBuilder) Order(order *OrderBuilder) *DeleteBuilder {
+	b.order = order
+	return b
+}
+
+// Limit sets LIMIT,
+// it's supported by mysql and sqlite compiled with SQLITE_ENABLE_UPDATE_DELETE_LIMIT
+//  var w = new(qb.WhereBuilder).Where("status", "=", "deleted")
+//  var b = new(qb.DeleteBuilder).From("users").Where(w).OrderBy("id").Limit(100).Grammar(qb.MysqlGrammar())
+//  _ = b.String() // DELETE FROM `users` WHERE `status` = ? ORDER BY `id` ASC LIMIT ?
+func (b *DeleteBuilder) Limit(limit int) *DeleteBuilder {
+	b.limit = limit
+	return b
+}
+
+// String implementations Stringer interface
+func (b *DeleteBuilder) String() string {
+	s, _, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Params returns parameters for query
+func (b *DeleteBuilder) Params() []interface{} {
+	_, params, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// Build returns the sql query string and parameters for query.
+// It returns ErrNoWhere if WHERE renders no expressions and All wasn't called,
+// ErrDeleteLimit if ORDER BY or LIMIT are set and the grammar doesn't support them,
+// and ErrDeleteOrder if ORDER BY is set without LIMIT and the grammar requires it.
+func (b *DeleteBuilder) Build() (string, []interface{}, error) {
+	return render(b, b.g())
+}
+
+func (b *DeleteBuilder) build(s *state) (string, []interface{}, error) {
+	var (
+		w      strings.Builder
+		params []interface{}
+		where  bool
+	)
+	w.WriteString("DELETE FROM ")
+	w.WriteString(s.wrap(b.table))
+
+	if b.where != nil {
+		q, args, err := s.build(b.where)
+		if err != nil {
+			return "", nil, err
+		}
+		if where = len(q) > 0; where {
+			w.WriteString(" WHERE ")
+			w.WriteString(q)
+			params = append(params, args...)
+		}
+	}
+	if !where && !b.all {
+		return "", nil, ErrNoWhere
+	}
+	if b.order != nil && len(b.order.groups) > 0 || b.limit != nil {
+		if g, ok := s.grammar.(DeleteLimitGrammar); !ok || !g.DeleteLimit() {
+			return "", nil, ErrDeleteLimit
+		}
+		if g, ok := s.grammar.(DeleteOrderGrammar); ok && g.DeleteOrderLimit() && b.limit == nil {
+			return "", nil, ErrDeleteOrder
+		}
+	}
+
+	if b.order != nil && len(b.order.groups) > 0 {
+		q, _, err := s.build(b.order)
+		if err != nil {
+			return "", nil, err
+		}
+		w.WriteString(" ORDER BY ")
+		w.WriteString(q)
+	}
+
+	if b.limit != nil {
+		p, err := s.placeholder(1)
+		if err != nil {
+			return "", nil, err
+		}
+		w.WriteString(" LIMIT " + p)
+		params = append(params, b.limit)
+	}
+
+	return w.String(), params, nil
+}
+
+// Grammar sets a Grammar
+func (b *DeleteBuilder) Grammar(grammar Grammar) Builder {
+	b.grammar = grammar
+	return b
+}
+
+func (b *DeleteBuilder) orderBuilder() *OrderBuilder {
+	if b.order == nil {
+		b.order = new(OrderBuilder)
+	}
+	return b.order
+}
+
+func (b *DeleteBuilder) g() Grammar {
+	if b.grammar == nil {
+		return defaultGrammar()
+	}
+	return b.grammar
+}
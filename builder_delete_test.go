@@ -0,0 +1,83 @@
+package qb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelete(t *testing.T) {
+	w := new(WhereBuilder).
+		Where("status", "=", "deleted").
+		WhereNotNull("deleted_at")
+	b := new(DeleteBuilder).
+		From("users").
+		Where(w)
+
+	assert.Equal(t, `DELETE FROM "users" WHERE "status" = $1 AND "deleted_at" IS NOT NULL`, b.String())
+	assert.Equal(t, []interface{}{"deleted"}, b.Params())
+}
+
+func TestDeleteAll(t *testing.T) {
+	b := new(DeleteBuilder).
+		From("users").
+		All()
+
+	assert.Equal(t, `DELETE FROM "users"`, b.String())
+	assert.Nil(t, b.Params())
+}
+
+func TestDeleteLimit(t *testing.T) {
+	w := new(WhereBuilder).Where("status", "=", "deleted")
+	b := new(DeleteBuilder).
+		From("users").
+		Where(w).
+		OrderBy("id").
+		Limit(100)
+
+	b.Grammar(MysqlGrammar())
+	assert.Equal(t, "DELETE FROM `users` WHERE `status` = ? ORDER BY `id` ASC LIMIT ?", b.String())
+	assert.Equal(t, []interface{}{"deleted", 100}, b.Params())
+
+	b.Grammar(SQLiteLimitGrammar())
+	assert.Equal(t, "DELETE FROM `users` WHERE `status` = ? ORDER BY `id` ASC LIMIT ?", b.String())
+
+	b.Grammar(SQLiteGrammar())
+	_, _, err := b.Build()
+	assert.True(t, errors.Is(err, ErrDeleteLimit))
+
+	b.Grammar(PgsqlGrammar())
+	_, _, err = b.Build()
+	assert.True(t, errors.Is(err, ErrDeleteLimit))
+}
+
+func TestDeleteOrderWithoutLimit(t *testing.T) {
+	w := new(WhereBuilder).Where("status", "=", "deleted")
+	b := new(DeleteBuilder).
+		From("users").
+		Where(w).
+		OrderBy("id")
+
+	b.Grammar(MysqlGrammar())
+	assert.Equal(t, "DELETE FROM `users` WHERE `status` = ? ORDER BY `id` ASC", b.String())
+
+	b.Grammar(SQLiteLimitGrammar())
+	_, _, err := b.Build()
+	assert.True(t, errors.Is(err, ErrDeleteOrder))
+
+	b.Grammar(StrictGrammar(SQLiteLimitGrammar(), nil))
+	_, _, err = b.Build()
+	assert.True(t, errors.Is(err, ErrDeleteOrder))
+
+	b.Limit(10).Grammar(SQLiteLimitGrammar())
+	assert.Equal(t, "DELETE FROM `users` WHERE `status` = ? ORDER BY `id` ASC LIMIT ?", b.String())
+}
+
+func TestDeleteErrors(t *testing.T) {
+	_, _, err := new(DeleteBuilder).From("users").Build()
+	assert.True(t, errors.Is(err, ErrNoWhere))
+
+	_, _, err = new(DeleteBuilder).From("users").Where(new(WhereBuilder)).Build()
+	assert.True(t, errors.Is(err, ErrNoWhere))
+}
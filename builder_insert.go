@@ -0,0 +1,129 @@
+package qb
+
+import "strings"
+
+// InsertBuilder builds INSERT queries
+//  var b = new(qb.InsertBuilder).
+//    Into("users").
+//    Columns("id", "name").
+//    Values(1, "Marty").
+//    Values(2, "Emmett")
+//  _ = b.String() // INSERT INTO "users" ("id", "name") VALUES ($1, $2), ($3, $4)
+//  _ = b.Params() // [1, "Marty", 2, "Emmett"]
+type InsertBuilder struct {
+	table   string
+	columns []string
+	values  *ValuesBuilder
+	grammar Grammar
+}
+
+// Into sets a table
+func (b *InsertBuilder) Into(table string) *InsertBuilder {
+	b.table = table
+	return b
+}
+
+// Columns adds columns to the column list
+func (b *InsertBuilder) Columns(columns ...string) *InsertBuilder {
+	b.columns = append(b.columns, columns...)
+	return b
+}
+
+// Values adds a new row, values must follow the order of the columns
+func (b *InsertBuilder) Values(values ...interface{}) *InsertBuilder {
+	if b.values == nil {
+		b.values = new(ValuesBuilder)
+	}
+	b.values.Values(values...)
+	return b
+}
+
+// Rows sets rows of the query, columns of structs added by ValuesBuilder.ValuesStruct
+// are used if the columns are not set
+//  var v = new(qb.ValuesBuilder).Values(1, "Marty").Values(2, "Emmett")
+//  var b = new(qb.InsertBuilder).Into("users").Columns("id", "name").Rows(v)
+//  _ = b.String() // INSERT INTO "users" ("id", "name") VALUES ($1, $2), ($3, $4)
+func (b *InsertBuilder) Rows(rows *ValuesBuilder) *InsertBuilder {
+	b.values = rows
+	return b
+}
+
+// String implementations Stringer interface
+func (b *InsertBuilder) String() string {
+	s, _, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Params returns parameters for query
+func (b *InsertBuilder) Params() []interface{} {
+	_, params, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// Build returns the sql query string and parameters for query.
+// It returns an error if there are no rows or a row doesn't match the columns.
+func (b *InsertBuilder) Build() (string, []interface{}, error) {
+	return render(b, b.g())
+}
+
+func (b *InsertBuilder) build(s *state) (string, []interface{}, error) {
+	if b.values != nil && b.values.err != nil {
+		return "", nil, b.values.err
+	}
+	if b.values == nil || len(b.values.rows) == 0 {
+		return "", nil, ErrNoValues
+	}
+	var columns = b.columns
+	if len(columns) == 0 {
+		columns = b.values.columns
+	}
+	if len(columns) > 0 {
+		for i, n := range b.values.rows {
+			if n != len(columns) {
+				return "", nil, &ValuesError{Row: i, Values: n, Columns: len(columns)}
+			}
+		}
+	}
+
+	var w strings.Builder
+	w.WriteString("INSERT INTO ")
+	w.WriteString(s.wrap(b.table))
+	if len(columns) > 0 {
+		w.WriteString(" (")
+		for i, column := range columns {
+			if i > 0 {
+				w.WriteString(", ")
+			}
+			w.WriteString(s.wrap(column))
+		}
+		w.WriteString(")")
+	}
+
+	q, params, err := s.build(b.values)
+	if err != nil {
+		return "", nil, err
+	}
+	w.WriteString(" VALUES ")
+	w.WriteString(q)
+
+	return w.String(), params, nil
+}
+
+// Grammar sets a Grammar
+func (b *InsertBuilder) Grammar(grammar Grammar) Builder {
+	b.grammar = grammar
+	return b
+}
+
+func (b *InsertBuilder) g() Grammar {
+	if b.grammar == nil {
+		return defaultGrammar()
+	}
+	return b.grammar
+}
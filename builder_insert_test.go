@@ -0,0 +1,50 @@
+package qb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsert(t *testing.T) {
+	b := new(InsertBuilder).
+		Into("users").
+		Columns("id", "name", "surname").
+		Values(1, "Marty", "McFly").
+		Values(2, "Emmett", "Brown")
+
+	assert.Equal(t, `INSERT INTO "users" ("id", "name", "surname") VALUES ($1, $2, $3), ($4, $5, $6)`, b.String())
+	assert.Equal(t, []interface{}{1, "Marty", "McFly", 2, "Emmett", "Brown"}, b.Params())
+}
+
+func TestInsertRows(t *testing.T) {
+	v := new(ValuesBuilder).
+		Values(1, "Marty").
+		Values(2, "Emmett")
+	b := new(InsertBuilder).
+		Into("users").
+		Columns("id", "name").
+		Rows(v).
+		Grammar(MysqlGrammar())
+
+	assert.Equal(t, "INSERT INTO `users` (`id`, `name`) VALUES (?, ?), (?, ?)", b.String())
+	assert.Equal(t, []interface{}{1, "Marty", 2, "Emmett"}, b.Params())
+}
+
+func TestInsertErrors(t *testing.T) {
+	var verr *ValuesError
+
+	_, _, err := new(InsertBuilder).Into("users").Columns("id").Build()
+	assert.True(t, errors.Is(err, ErrNoValues))
+
+	_, _, err = new(InsertBuilder).
+		Into("users").
+		Columns("id", "name").
+		Values(1, "Marty").
+		Values(2).
+		Build()
+	assert.True(t, errors.Is(err, ErrValuesMismatch))
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, ValuesError{Row: 1, Values: 1, Columns: 2}, *verr)
+}
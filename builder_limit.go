@@ -0,0 +1,113 @@
+package qb
+
+// LimitGrammar is implemented by grammars which render LIMIT and OFFSET in their own way.
+// Limit and offset bind placeholders of the values and return them, they are nil if a value is not set.
+// They must be called in the order the placeholders appear in the query,
+// e.g. OFFSET ... FETCH of SQL Server calls offset first.
+type LimitGrammar interface {
+	Grammar
+	Limit(limit, offset func() string) string
+}
+
+// LimitBuilder builds LIMIT and OFFSET expressions
+//  var b = new(qb.LimitBuilder).Limit(10).Offset(20)
+//  _ = b.String() // LIMIT $1 OFFSET $2
+//  _ = b.Params() // [10, 20]
+type LimitBuilder struct {
+	limit   interface{}
+	offset  interface{}
+	grammar Grammar
+}
+
+// Limit sets LIMIT
+func (b *LimitBuilder) Limit(limit int) *LimitBuilder {
+	b.limit = limit
+	return b
+}
+
+// Offset sets OFFSET
+func (b *LimitBuilder) Offset(offset int) *LimitBuilder {
+	b.offset = offset
+	return b
+}
+
+// Page sets LIMIT and OFFSET of the page, pages start from 1
+//  var b = new(qb.LimitBuilder).Page(3, 10)
+//  _ = b.String() // LIMIT $1 OFFSET $2
+//  _ = b.Params() // [10, 20]
+func (b *LimitBuilder) Page(page, size int) *LimitBuilder {
+	if page < 1 {
+		page = 1
+	}
+	b.limit = size
+	b.offset = (page - 1) * size
+	return b
+}
+
+// String implementations Stringer interface
+func (b *LimitBuilder) String() string {
+	s, _, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Params returns parameters for query
+func (b *LimitBuilder) Params() []interface{} {
+	_, params, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// Build returns the sql expression and parameters for query
+func (b *LimitBuilder) Build() (string, []interface{}, error) {
+	return render(b, b.g())
+}
+
+func (b *LimitBuilder) build(s *state) (string, []interface{}, error) {
+	var (
+		params        []interface{}
+		limit, offset func() string
+	)
+	var bind = func(v interface{}) func() string {
+		return func() string {
+			p, _ := s.placeholder(1)
+			params = append(params, v)
+			return p
+		}
+	}
+	if b.limit != nil {
+		limit = bind(b.limit)
+	}
+	if b.offset != nil {
+		offset = bind(b.offset)
+	}
+	if g, ok := s.grammar.(LimitGrammar); ok {
+		return g.Limit(limit, offset), params, nil
+	}
+	switch {
+	case limit != nil && offset != nil:
+		return "LIMIT " + limit() + " OFFSET " + offset(), params, nil
+	case limit != nil:
+		return "LIMIT " + limit(), params, nil
+	case offset != nil:
+		return "OFFSET " + offset(), params, nil
+	}
+	return "", nil, nil
+}
+
+// Grammar sets a Grammar
+func (b *LimitBuilder) Grammar(grammar Grammar) Builder {
+	b.grammar = grammar
+	return b
+}
+
+func (b *LimitBuilder) g() Grammar {
+	if b.grammar == nil {
+		return defaultGrammar()
+	}
+	return b.grammar
+}
@@ -0,0 +1,69 @@
+package qb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimit(t *testing.T) {
+	b := new(LimitBuilder).Limit(10).Offset(20)
+	q := Query("SELECT id FROM table WHERE status = %p %s", "active", b)
+
+	assert.Equal(t, `SELECT id FROM table WHERE status = $1 LIMIT $2 OFFSET $3`, q.String())
+	assert.Equal(t, []interface{}{"active", 10, 20}, q.Params())
+
+	assert.Equal(t, `LIMIT $1`, new(LimitBuilder).Limit(10).String())
+	assert.Equal(t, `OFFSET $1`, new(LimitBuilder).Offset(20).String())
+	assert.Equal(t, ``, new(LimitBuilder).String())
+}
+
+func TestLimitPage(t *testing.T) {
+	b := new(LimitBuilder).Page(3, 10)
+	assert.Equal(t, `LIMIT $1 OFFSET $2`, b.String())
+	assert.Equal(t, []interface{}{10, 20}, b.Params())
+
+	b = new(LimitBuilder).Page(0, 10)
+	assert.Equal(t, []interface{}{10, 0}, b.Params())
+}
+
+func TestLimitGrammar(t *testing.T) {
+	var b = new(LimitBuilder).Offset(20)
+
+	b.Grammar(MysqlGrammar())
+	assert.Equal(t, `LIMIT 18446744073709551615 OFFSET ?`, b.String())
+
+	b.Grammar(SQLiteGrammar())
+	assert.Equal(t, `LIMIT -1 OFFSET ?`, b.String())
+
+	b.Limit(10).Grammar(mssqlGrammar{})
+	assert.Equal(t, `OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY`, b.String())
+	assert.Equal(t, []interface{}{20, 10}, b.Params())
+}
+
+// mssqlGrammar renders LIMIT and OFFSET with OFFSET ... FETCH
+type mssqlGrammar struct{}
+
+func (mssqlGrammar) Wrap(s string) string { return "[" + s + "]" }
+
+func (mssqlGrammar) Placeholder(offset, n int) string {
+	var s string
+	for i := 1; i <= n; i++ {
+		if i > 1 {
+			s += ", "
+		}
+		s += "@p" + toString(offset+i)
+	}
+	return s
+}
+
+func (mssqlGrammar) Limit(limit, offset func() string) string {
+	var s = "OFFSET 0 ROWS"
+	if offset != nil {
+		s = "OFFSET " + offset() + " ROWS"
+	}
+	if limit != nil {
+		s += " FETCH NEXT " + limit() + " ROWS ONLY"
+	}
+	return s
+}
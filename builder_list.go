@@ -4,10 +4,8 @@ import "strings"
 
 // ListBuilder builds list of placeholders
 type ListBuilder struct {
-	groups  []func() string
-	params  []interface{}
+	groups  []func(s *state) (string, []interface{}, error)
 	grammar Grammar
-	regular bool
 }
 
 // Append appends new values to the list
@@ -18,47 +16,73 @@ func (b *ListBuilder) Append(values ...interface{}) *ListBuilder {
 	if len(values) == 0 {
 		return b
 	}
-	b.params = append(b.params, values...)
-	b.groups = append(b.groups, func() string {
-		return ", " + b.g().Placeholder(len(values))
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		p, params, err := s.values(values)
+		return ", " + p, params, err
 	})
 	return b
 }
 
+// AppendSlice appends values of any slice to the list,
+// an empty slice appends nothing, a value which isn't a slice is a single value
+//  var b = new(qb.ListBuilder).AppendSlice([]string{"one", "two"})
+//  _ = b.String() // $1, $2
+//  _ = b.Params() // ["one", "two"]
+func (b *ListBuilder) AppendSlice(slice interface{}) *ListBuilder {
+	return b.Append(sliceValues(slice)...)
+}
+
 // String implementations Stringer interface
 func (b *ListBuilder) String() string {
-	if len(b.groups) == 0 {
-		return ""
-	}
-	defer b.r()
-	var s strings.Builder
-	for _, f := range b.groups {
-		s.WriteString(f())
+	s, _, err := b.Build()
+	if err != nil {
+		panic(err)
 	}
-	return s.String()[2:]
+	return s
 }
 
 // Params returns parameters for query
 func (b *ListBuilder) Params() []interface{} {
-	return b.params
+	_, params, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// Build returns the sql expression and parameters for query
+func (b *ListBuilder) Build() (string, []interface{}, error) {
+	return render(b, b.g())
+}
+
+func (b *ListBuilder) build(s *state) (string, []interface{}, error) {
+	if len(b.groups) == 0 {
+		return "", nil, nil
+	}
+	var (
+		w      strings.Builder
+		params []interface{}
+	)
+	for _, f := range b.groups {
+		q, args, err := f(s)
+		if err != nil {
+			return "", nil, err
+		}
+		w.WriteString(q)
+		params = append(params, args...)
+	}
+	return w.String()[2:], params, nil
 }
 
 // Grammar sets a Grammar
 func (b *ListBuilder) Grammar(grammar Grammar) Builder {
 	b.grammar = grammar
-	b.regular = true
 	return b
 }
 
 func (b *ListBuilder) g() Grammar {
 	if b.grammar == nil {
-		b.grammar = grammar()
+		return defaultGrammar()
 	}
 	return b.grammar
 }
-
-func (b *ListBuilder) r() {
-	if !b.regular {
-		b.grammar = grammar()
-	}
-}
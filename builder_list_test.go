@@ -32,3 +32,14 @@ func TestListMySQLGrammar(t *testing.T) {
 	assert.Equal(t, `SELECT id FROM table WHERE name ?| ARRAY[?, ?, ?]`, q.String())
 	assert.Equal(t, []interface{}{"one", "two", "three"}, q.Params())
 }
+
+func TestListAppendSlice(t *testing.T) {
+	b := new(ListBuilder).
+		AppendSlice([]string{"one", "two"}).
+		AppendSlice([]int64{}).
+		AppendSlice(testIDs{3}).
+		AppendSlice("four")
+	q := Query("SELECT id FROM table WHERE name ?| ARRAY[%s]", b)
+	assert.Equal(t, `SELECT id FROM table WHERE name ?| ARRAY[$1, $2, $3, $4]`, q.String())
+	assert.Equal(t, []interface{}{"one", "two", int64(3), "four"}, q.Params())
+}
@@ -0,0 +1,109 @@
+package qb
+
+import "strings"
+
+// OrderGrammar is implemented by grammars
+// which render ORDER BY expressions in their own way
+type OrderGrammar interface {
+	Grammar
+	Order(column, direction, nulls string) string
+}
+
+// OrderBuilder builds ORDER BY expressions.
+// Columns are wrapped as identifiers, a typecast is kept unquoted only if it is well-formed,
+// directions and orders of nulls are validated, so they may come from user input.
+// Any column may still be requested, check field names against known columns if it matters.
+type OrderBuilder struct {
+	groups  []func(s *state) (string, error)
+	grammar Grammar
+}
+
+// Order adds an expression, the direction is ASC or DESC in any case, empty means ASC
+//  var b = new(qb.OrderBuilder).Order("name", "asc").Order("id", "DESC")
+//  _ = b.String() // "name" ASC, "id" DESC
+func (b *OrderBuilder) Order(field, direction string) *OrderBuilder {
+	return b.OrderNulls(field, direction, "")
+}
+
+// OrderNulls adds an expression with an order of nulls,
+// nulls is FIRST or LAST in any case, empty means the database default.
+// Mysql doesn't support NULLS FIRST and NULLS LAST, they are emulated with ISNULL(column)
+//  var b = new(qb.OrderBuilder).OrderNulls("deleted_at", "desc", "last")
+//  _ = b.String() // "deleted_at" DESC NULLS LAST
+//  _ = b.Grammar(qb.MysqlGrammar()).String() // ISNULL(`deleted_at`) ASC, `deleted_at` DESC
+func (b *OrderBuilder) OrderNulls(field, direction, nulls string) *OrderBuilder {
+	var err error
+	switch direction = strings.ToUpper(strings.TrimSpace(direction)); direction {
+	case "":
+		direction = "ASC"
+	case "ASC", "DESC":
+	default:
+		err = ErrInvalidDirection
+	}
+	switch nulls = strings.ToUpper(strings.TrimSpace(nulls)); nulls {
+	case "", "FIRST", "LAST":
+	default:
+		err = ErrInvalidNulls
+	}
+	b.groups = append(b.groups, func(s *state) (string, error) {
+		if err != nil {
+			return "", err
+		}
+		if g, ok := s.grammar.(OrderGrammar); ok {
+			return ", " + g.Order(s.wrap(field), direction, nulls), nil
+		}
+		if len(nulls) > 0 {
+			return ", " + s.wrap(field) + " " + direction + " NULLS " + nulls, nil
+		}
+		return ", " + s.wrap(field) + " " + direction, nil
+	})
+	return b
+}
+
+// String implementations Stringer interface
+func (b *OrderBuilder) String() string {
+	s, _, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Params returns parameters for query
+func (b *OrderBuilder) Params() []interface{} {
+	return nil
+}
+
+// Build returns the sql expression and parameters for query.
+// It returns ErrInvalidDirection or ErrInvalidNulls for invalid input.
+func (b *OrderBuilder) Build() (string, []interface{}, error) {
+	return render(b, b.g())
+}
+
+func (b *OrderBuilder) build(s *state) (string, []interface{}, error) {
+	if len(b.groups) == 0 {
+		return "", nil, nil
+	}
+	var w strings.Builder
+	for _, f := range b.groups {
+		q, err := f(s)
+		if err != nil {
+			return "", nil, err
+		}
+		w.WriteString(q)
+	}
+	return w.String()[2:], nil, nil
+}
+
+// Grammar sets a Grammar
+func (b *OrderBuilder) Grammar(grammar Grammar) Builder {
+	b.grammar = grammar
+	return b
+}
+
+func (b *OrderBuilder) g() Grammar {
+	if b.grammar == nil {
+		return defaultGrammar()
+	}
+	return b.grammar
+}
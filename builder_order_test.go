@@ -0,0 +1,69 @@
+package qb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder(t *testing.T) {
+	b := new(OrderBuilder).
+		Order("name", "asc").
+		Order("u.id", " Desc ").
+		Order("created_at", "")
+
+	assert.Equal(t, `"name" ASC, "u"."id" DESC, "created_at" ASC`, b.String())
+	assert.Nil(t, b.Params())
+}
+
+func TestOrderNulls(t *testing.T) {
+	b := new(OrderBuilder).
+		OrderNulls("deleted_at", "desc", "last").
+		OrderNulls("name", "asc", "FIRST").
+		Order("id", "asc")
+
+	assert.Equal(t, `"deleted_at" DESC NULLS LAST, "name" ASC NULLS FIRST, "id" ASC`, b.String())
+
+	b.Grammar(SQLiteGrammar())
+	assert.Equal(t, "`deleted_at` DESC NULLS LAST, `name` ASC NULLS FIRST, `id` ASC", b.String())
+
+	b.Grammar(MysqlGrammar())
+	assert.Equal(t, "ISNULL(`deleted_at`) ASC, `deleted_at` DESC, ISNULL(`name`) DESC, `name` ASC, `id` ASC", b.String())
+}
+
+func TestOrderTypecast(t *testing.T) {
+	b := new(OrderBuilder).
+		Order("name::text", "asc").
+		Order("id::int, 1 --", "desc")
+
+	assert.Equal(t, `"name"::text ASC, "id::int, 1 --" DESC`, b.String())
+}
+
+func TestOrderQuery(t *testing.T) {
+	o := new(OrderBuilder).Order("id", "desc")
+	q := Query("SELECT id FROM table WHERE status = %p ORDER BY %s LIMIT %p", "active", o, 10)
+
+	assert.Equal(t, `SELECT id FROM table WHERE status = $1 ORDER BY "id" DESC LIMIT $2`, q.String())
+	assert.Equal(t, []interface{}{"active", 10}, q.Params())
+}
+
+func TestOrderErrors(t *testing.T) {
+	_, _, err := new(OrderBuilder).Order("id", "asc; DROP TABLE users").Build()
+	assert.True(t, errors.Is(err, ErrInvalidDirection))
+
+	_, _, err = new(OrderBuilder).OrderNulls("id", "asc", "middle").Build()
+	assert.True(t, errors.Is(err, ErrInvalidNulls))
+
+	q, _, err := new(OrderBuilder).Order("id::text; DROP TABLE users; --", "asc").Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `"id::text; DROP TABLE users; --" ASC`, q)
+
+	q, _, err = new(OrderBuilder).OrderNulls(`id" ASC; DROP TABLE users; --`, "desc", "last").Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `"id"" ASC; DROP TABLE users; --" DESC NULLS LAST`, q)
+
+	o := new(OrderBuilder).Order("id", "up")
+	_, _, err = new(SelectBuilder).From("users").Order(o).Build()
+	assert.True(t, errors.Is(err, ErrInvalidDirection))
+}
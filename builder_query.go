@@ -9,161 +9,333 @@
 package qb
 
 import (
+	"reflect"
 	"strings"
+	"sync"
 )
 
 var (
+	mu       sync.RWMutex
 	grammar  = PgsqlGrammar
 	grammars = map[string]func() Grammar{}
 )
 
 type (
-	// Grammar interface
+	// Grammar interface.
+	// Placeholder returns n count placeholders following offset already rendered ones,
+	// it must not keep a state, so one grammar can be shared between builders.
 	Grammar interface {
 		Wrap(s string) string
-		Placeholder(n int) string
+		Placeholder(offset, n int) string
 	}
 
-	// Builder interface
+	// Builder interface.
+	// A builder implemented outside of the package is nested with Grammar called on its copy,
+	// Grammar of a builder which isn't a pointer must not change a state shared with the caller.
 	Builder interface {
 		String() string
 		Params() []interface{}
 		Grammar(Grammar) Builder
+		Build() (string, []interface{}, error)
+	}
+
+	// builder is implemented by the package builders,
+	// it renders a builder with the state without changing the builder
+	builder interface {
+		build(s *state) (string, []interface{}, error)
+	}
+
+	// NumberedGrammar is implemented by grammars with numbered placeholders,
+	// a repeated named parameter reuses its placeholder, e.g. $1
+	NumberedGrammar interface {
+		Grammar
+		Numbered() bool
 	}
 
 	// Format query
 	format struct {
 		query   string
 		params  []interface{}
+		named   map[string]interface{} // named parameters
+		isNamed bool                   // named verbs are enabled, see QueryNamed
+		err     error                  // invalid named parameters
 		grammar Grammar
-		regular bool
 	}
 )
 
-// DefaultGrammar sets a default grammar
+// DefaultGrammar sets a default grammar, it panics if the grammar is not registered
 func DefaultGrammar(name string) {
-	var ok bool
-	if grammar, ok = grammars[name]; !ok {
-		panic("qb: grammar '" + name + "' not found")
+	if err := SetDefaultGrammar(name); err != nil {
+		panic(err)
 	}
 }
 
+// SetDefaultGrammar sets a default grammar or returns an error if the grammar is not registered
+func SetDefaultGrammar(name string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	g, ok := grammars[name]
+	if !ok {
+		return &GrammarError{Name: name}
+	}
+	grammar = g
+	return nil
+}
+
 // RegisterGrammar registers a new grammar
 func RegisterGrammar(name string, grammar func() Grammar) {
+	mu.Lock()
+	defer mu.Unlock()
 	grammars[name] = grammar
 }
 
-// Query formats according to a format specifier and returns the sql query string
+// NewGrammar returns a new instance of the registered grammar
+//  var q = qb.Query("SELECT id FROM table WHERE id = %p", 1)
+//  g, err := qb.NewGrammar("mysql")
+//  s, params, err := qb.Build(q, g) // SELECT id FROM table WHERE id = ?
+func NewGrammar(name string) (Grammar, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	g, ok := grammars[name]
+	if !ok {
+		return nil, &GrammarError{Name: name}
+	}
+	return g(), nil
+}
+
+// Build returns the sql query string and parameters of the builder rendered with the grammar.
+// Unlike Builder.Grammar it doesn't change the builder, so one builder
+// can be rendered with different grammars at the same time.
+func Build(b Builder, g Grammar) (string, []interface{}, error) {
+	return render(b, g)
+}
+
+// defaultGrammar returns an instance of the default grammar
+func defaultGrammar() Grammar {
+	mu.RLock()
+	defer mu.RUnlock()
+	return grammar()
+}
+
+// Query formats according to a format specifier and returns the sql query string.
+// %s inserts a string, an expression or a builder as is, %p inserts a placeholder,
+// an expression (see Expr) or a builder in parentheses,
+// %i wraps an identifier or a []string of identifiers in quotes,
+// %l inserts placeholders of a slice values, %% inserts a percent sign
 //  var q = qb.Query("SELECT id FROM table WHERE name = %p LIMIT %p OFFSET %p", "Tom", 10, 0)
 //  _ = b.String() // SELECT id FROM table WHERE name = $1 LIMIT $2 OFFSET $3
 //  _ = b.Params() // ["Tom", 10, 0]
 func Query(query string, params ...interface{}) Builder {
 	return &format{
-		query:   query,
-		params:  params,
-		grammar: grammar(),
+		query:  query,
+		params: params,
+	}
+}
+
+// QueryNamed formats according to a format specifier with named verbs
+// %(name)s and %(name)p bound from a map with string keys or a struct with db tags.
+// On grammars with numbered placeholders a repeated name reuses its placeholder,
+// on other grammars the value is repeated
+//  var q = qb.QueryNamed("SELECT id FROM table WHERE %(where)s AND (owner = %(user)p OR editor = %(user)p)", map[string]interface{}{
+//    "where": new(qb.WhereBuilder).Where("status", "=", "active"),
+//    "user":  10,
+//  })
+//  _ = q.String() // SELECT id FROM table WHERE "status" = $1 AND (owner = $2 OR editor = $2)
+//  _ = q.Params() // ["active", 10]
+func QueryNamed(query string, params interface{}) Builder {
+	var f = &format{query: query, isNamed: true}
+	f.named, f.err = namedParams(params)
+	return f
+}
+
+// namedParams converts a map with string keys or a struct to named parameters
+func namedParams(v interface{}) (map[string]interface{}, error) {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m, nil
+	}
+	var rv = reflect.ValueOf(v)
+	if rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String {
+		var m = make(map[string]interface{}, rv.Len())
+		for it := rv.MapRange(); it.Next(); {
+			m[it.Key().String()] = it.Value().Interface()
+		}
+		return m, nil
+	}
+	rv, err := structValue(v)
+	if err != nil {
+		return nil, ErrNamedParams
+	}
+	var (
+		fields = structFields(rv.Type())
+		m      = make(map[string]interface{}, len(fields))
+	)
+	for _, f := range fields {
+		if fv, ok := f.value(rv); ok {
+			m[f.column] = fv.Interface()
+		}
 	}
+	return m, nil
 }
 
 // String implementations Stringer interface
 func (f *format) String() string {
-	defer f.r()
+	s, _, err := f.Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Params returns parameters for query
+func (f *format) Params() []interface{} {
+	_, params, err := f.Build()
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// Build returns the sql query string and parameters for query
+func (f *format) Build() (string, []interface{}, error) {
+	return render(f, f.g())
+}
+
+func (f *format) build(st *state) (string, []interface{}, error) {
+	if f.err != nil {
+		return "", nil, f.err
+	}
 	var (
-		b strings.Builder
-		p int
-		s int
-		r bool
+		b      strings.Builder
+		params = make([]interface{}, 0, len(f.params))
+		seen   map[string]string // placeholders of named parameters
+		p      int
+		s      int
+		r      bool
 	)
 	for i := 0; i < len(f.query); i++ {
-		switch {
-		case f.query[i] == '%':
+		switch c := f.query[i]; {
+		case c == '%':
 			if r = !r; !r {
 				b.WriteString(f.query[s : i-1])
 				b.WriteString(f.query[i : i+1])
 				s = i + 1
 			}
-		case f.query[i] == 's' && r:
+		case !r:
+		case c == 's' || c == 'p' || c == 'i' || c == 'l':
 			if p >= len(f.params) {
-				panic("qb: parameter not found")
+				return "", nil, f.error(i-1, ErrTooFewParams)
 			}
-			if b, ok := f.params[p].(Builder); ok {
-				b.Grammar(f.g())
+			q, args, err := f.verb(st, i-1, c, f.params[p])
+			if err != nil {
+				return "", nil, err
 			}
 			b.WriteString(f.query[s : i-1])
-			b.WriteString(toString(f.params[p]))
+			b.WriteString(q)
+			params = append(params, args...)
 			s = i + 1
 			r = false
 			p++
-		case f.query[i] == 'p' && r:
-			if p >= len(f.params) {
-				panic("qb: parameter not found")
+		case c == '(' && f.isNamed:
+			var j = strings.IndexByte(f.query[i:], ')') + i
+			if j < i || j+1 >= len(f.query) || strings.IndexByte("spil", f.query[j+1]) < 0 {
+				return "", nil, f.error(i-1, ErrUnknownVerb)
+			}
+			var name, verb = f.query[i+1 : j], f.query[j+1]
+			v, ok := f.named[name]
+			if !ok {
+				return "", nil, f.error(i-1, ErrMissingParam)
 			}
 			b.WriteString(f.query[s : i-1])
-			b.WriteString(f.g().Placeholder(1))
-			s = i + 1
+			if g, ok := st.grammar.(NumberedGrammar); ok && g.Numbered() && verb == 'p' && !isExpr(v) {
+				ph, ok := seen[name]
+				if !ok {
+					var err error
+					if ph, err = st.placeholder(1); err != nil {
+						return "", nil, err
+					}
+					if seen == nil {
+						seen = map[string]string{}
+					}
+					seen[name] = ph
+					params = append(params, v)
+				}
+				b.WriteString(ph)
+			} else {
+				q, args, err := f.verb(st, i-1, verb, v)
+				if err != nil {
+					return "", nil, err
+				}
+				b.WriteString(q)
+				params = append(params, args...)
+			}
+			s = j + 2
+			i = j + 1
 			r = false
-			p++
+		case 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+			return "", nil, f.error(i-1, ErrUnknownVerb)
 		default:
 			r = false
 		}
 	}
+	if p < len(f.params) {
+		return "", nil, f.error(-1, ErrTooManyParams)
+	}
 	b.WriteString(f.query[s:])
-	return b.String()
+	return b.String(), params, nil
 }
 
-// Params returns parameters for query
-func (f *format) Params() []interface{} {
-	var (
-		params = make([]interface{}, 0, len(f.params))
-		record = false
-	)
-	for i, p := 0, 0; i < len(f.query); i++ {
-		switch {
-		case f.query[i] == '%':
-			record = !record
-		case f.query[i] == 's' && record:
-			if p >= len(f.params) {
-				panic("qb: parameter not found")
-			}
-			if b, ok := f.params[p].(Builder); ok {
-				params = append(params, b.Params()...)
-			}
-			p++
-			record = false
-		case f.query[i] == 'p' && record:
-			if p >= len(f.params) {
-				panic("qb: parameter not found")
+// verb renders a parameter of the verb at the position
+func (f *format) verb(st *state, pos int, verb byte, v interface{}) (string, []interface{}, error) {
+	switch verb {
+	case 'p':
+		return st.value(v)
+	case 'i':
+		switch x := v.(type) {
+		case string:
+			return wrapColumn(st, x), nil, nil
+		case []string:
+			if len(x) == 0 {
+				return "", nil, f.error(pos, ErrEmptyList)
 			}
-			if b, ok := f.params[p].(Builder); ok {
-				params = append(params, b.Params()...)
-			} else {
-				params = append(params, f.params[p])
+			var w strings.Builder
+			for i, column := range x {
+				if i > 0 {
+					w.WriteString(", ")
+				}
+				w.WriteString(wrapColumn(st, column))
 			}
-			p++
-			record = false
-		default:
-			record = false
+			return w.String(), nil, nil
 		}
+		return "", nil, f.error(pos, ErrInvalidIdentifier)
+	case 'l':
+		var values = sliceValues(v)
+		if len(values) == 0 {
+			return "", nil, f.error(pos, ErrEmptyList)
+		}
+		return st.values(values)
 	}
-	return params
+	switch x := v.(type) {
+	case Expr:
+		return x.expr(st)
+	case Builder:
+		return st.build(x)
+	}
+	return toString(v), nil, nil
 }
 
 // Grammar sets a Grammar
 func (f *format) Grammar(grammar Grammar) Builder {
 	f.grammar = grammar
-	f.regular = true
 	return f
 }
 
 func (f *format) g() Grammar {
 	if f.grammar == nil {
-		f.grammar = grammar()
+		return defaultGrammar()
 	}
 	return f.grammar
 }
 
-func (f *format) r() {
-	if !f.regular {
-		f.grammar = grammar()
-	}
+func (f *format) error(pos int, err error) error {
+	return &FormatError{Query: f.query, Pos: pos, Err: err}
 }
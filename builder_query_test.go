@@ -1,6 +1,8 @@
 package qb
 
 import (
+	"errors"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -52,6 +54,224 @@ func TestBuilder_Glammar(t *testing.T) {
 	)
 }
 
+func TestBuilder_Build(t *testing.T) {
+	b := new(WhereBuilder).Where("name", "=", "test")
+	q, params, err := Query("SELECT id FROM table WHERE %s LIMIT %p", b, 10).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id FROM table WHERE "name" = $1 LIMIT $2`, q)
+	assert.Equal(t, []interface{}{"test", 10}, params)
+}
+
+func TestBuilder_BuildErrors(t *testing.T) {
+	var ferr *FormatError
+
+	_, _, err := Query("SELECT id FROM table WHERE id = %p AND name = %p", 1).Build()
+	assert.True(t, errors.Is(err, ErrTooFewParams))
+	assert.True(t, errors.As(err, &ferr))
+	assert.Equal(t, 46, ferr.Pos)
+
+	_, _, err = Query("SELECT id FROM table WHERE id = %p", 1, 2).Build()
+	assert.True(t, errors.Is(err, ErrTooManyParams))
+
+	_, _, err = Query("SELECT id FROM table WHERE id = %d", 1).Build()
+	assert.True(t, errors.Is(err, ErrUnknownVerb))
+	assert.True(t, errors.As(err, &ferr))
+	assert.Equal(t, 32, ferr.Pos)
+
+	// a percent sign followed by a letter is a verb, literal percent signs are written as %%
+	_, _, err = Query("SELECT id FROM table WHERE name LIKE '%abc%'").Build()
+	assert.True(t, errors.Is(err, ErrUnknownVerb))
+	assert.True(t, errors.As(err, &ferr))
+	assert.Equal(t, 38, ferr.Pos)
+
+	q, _, err := Query("SELECT id FROM table WHERE name LIKE '%%abc%%' AND id = %p", 1).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id FROM table WHERE name LIKE '%abc%' AND id = $1`, q)
+
+	_, _, err = new(WhereBuilder).WhereRaw("id = %p").Build()
+	assert.True(t, errors.Is(err, ErrTooFewParams))
+
+	_, _, err = Query("SELECT id FROM table WHERE %s", new(WhereBuilder).WhereRaw("id = %p")).Build()
+	assert.True(t, errors.Is(err, ErrTooFewParams))
+
+	assert.Panics(t, func() {
+		_ = Query("SELECT id FROM table WHERE id = %p").String()
+	})
+}
+
+func TestBuilder_SetDefaultGrammar(t *testing.T) {
+	err := SetDefaultGrammar("oracle")
+	assert.True(t, errors.Is(err, ErrUnknownGrammar))
+	assert.EqualError(t, err, "qb: unknown grammar 'oracle'")
+
+	assert.Panics(t, func() {
+		DefaultGrammar("oracle")
+	})
+}
+
+func TestBuilder_NewGrammar(t *testing.T) {
+	g, err := NewGrammar("mysql")
+	assert.NoError(t, err)
+	assert.Equal(t, MysqlGrammar(), g)
+
+	_, err = NewGrammar("oracle")
+	assert.True(t, errors.Is(err, ErrUnknownGrammar))
+}
+
+func TestBuilder_BuildGrammar(t *testing.T) {
+	b := new(WhereBuilder).Where("name", "=", "test")
+	q := Query("SELECT id FROM table WHERE %s LIMIT %p", b, 10)
+
+	s, params, err := Build(q, MysqlGrammar())
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM table WHERE `name` = ? LIMIT ?", s)
+	assert.Equal(t, []interface{}{"test", 10}, params)
+
+	assert.Equal(t, `SELECT id FROM table WHERE "name" = $1 LIMIT $2`, q.String())
+}
+
+func TestBuilder_Idempotent(t *testing.T) {
+	b := new(WhereBuilder).Where("name", "=", "test")
+	q := Query("SELECT id FROM table WHERE %s LIMIT %p", b, 10).Grammar(PgsqlGrammar())
+	assert.Equal(t, `SELECT id FROM table WHERE "name" = $1 LIMIT $2`, q.String())
+	assert.Equal(t, `SELECT id FROM table WHERE "name" = $1 LIMIT $2`, q.String())
+	assert.Equal(t, []interface{}{"test", 10}, q.Params())
+	assert.Equal(t, `SELECT id FROM table WHERE "name" = $1 LIMIT $2`, q.String())
+	assert.Equal(t, `"name" = $1`, b.String())
+}
+
+func TestBuilder_ForeignBuilder(t *testing.T) {
+	b := new(WhereBuilder).
+		Where("status", "=", "active").
+		WhereInSub("id", foreignBuilder{})
+	assert.Equal(t, `"status" = $1 AND "id" IN (SELECT id FROM table WHERE name = $2 OR name = $3)`, b.String())
+	assert.Equal(t, []interface{}{"active", "Tom", "Ann"}, b.Params())
+}
+
+type foreignBuilder struct {
+	grammar Grammar
+}
+
+func (b foreignBuilder) String() string {
+	return "SELECT id FROM table WHERE name = " + b.grammar.Placeholder(0, 1) + " OR name = " + b.grammar.Placeholder(1, 1)
+}
+
+func (b foreignBuilder) Params() []interface{} {
+	return []interface{}{"Tom", "Ann"}
+}
+
+func (b foreignBuilder) Grammar(g Grammar) Builder {
+	return foreignBuilder{g}
+}
+
+func (b foreignBuilder) Build() (string, []interface{}, error) {
+	if b.grammar == nil {
+		b.grammar = PgsqlGrammar()
+	}
+	return b.String(), b.Params(), nil
+}
+
+type foreignPtrBuilder struct {
+	name    string
+	grammar Grammar
+}
+
+func (b *foreignPtrBuilder) String() string {
+	s, _, _ := b.Build()
+	return s
+}
+
+func (b *foreignPtrBuilder) Params() []interface{} {
+	return []interface{}{b.name}
+}
+
+func (b *foreignPtrBuilder) Grammar(g Grammar) Builder {
+	b.grammar = g
+	return b
+}
+
+func (b *foreignPtrBuilder) Build() (string, []interface{}, error) {
+	var g = b.grammar
+	if g == nil {
+		g = PgsqlGrammar()
+	}
+	return "SELECT id FROM users WHERE name = " + g.Placeholder(0, 1), b.Params(), nil
+}
+
+// Run with -race
+func TestBuilder_ForeignPtrBuilder(t *testing.T) {
+	var (
+		wg sync.WaitGroup
+		f  = &foreignPtrBuilder{name: "Tom"}
+		b  = new(WhereBuilder).
+			Where("status", "=", "active").
+			Where("role", "=", "admin").
+			WhereInSub("id", f)
+	)
+	assert.Equal(t, `SELECT id FROM users WHERE name = $1`, f.String())
+	assert.Equal(t, `"status" = $1 AND "role" = $2 AND "id" IN (SELECT id FROM users WHERE name = $3)`, b.String())
+	assert.Equal(t, `SELECT id FROM users WHERE name = $1`, f.String())
+	assert.Nil(t, f.grammar)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, `"status" = $1 AND "role" = $2 AND "id" IN (SELECT id FROM users WHERE name = $3)`, b.String())
+			s, params, err := Build(Query("SELECT %p, %s", 1, f), MysqlGrammar())
+			assert.NoError(t, err)
+			assert.Equal(t, "SELECT ?, SELECT id FROM users WHERE name = ?", s)
+			assert.Equal(t, []interface{}{1, "Tom"}, params)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, `SELECT id FROM users WHERE name = $1`, f.String())
+}
+
+// Run with -race
+func TestBuilder_Concurrency(t *testing.T) {
+	var (
+		wg     sync.WaitGroup
+		shared = Query("SELECT id FROM table WHERE %s LIMIT %p", new(WhereBuilder).Where("name", "=", "test"), 10)
+		expect = map[string]string{
+			"postgres": `SELECT id FROM table WHERE "name" = $1 LIMIT $2`,
+			"mysql":    "SELECT id FROM table WHERE `name` = ? LIMIT ?",
+			"sqlite3":  "SELECT id FROM table WHERE `name` = ? LIMIT ?",
+		}
+		cached = map[string]Builder{}
+	)
+	for name := range expect {
+		g, err := NewGrammar(name)
+		assert.NoError(t, err)
+		b := new(WhereBuilder).Where("name", "=", "test")
+		cached[name] = Query("SELECT id FROM table WHERE %s LIMIT %p", b, 10).Grammar(g)
+	}
+	for i := 0; i < 10; i++ {
+		for name, sql := range expect {
+			wg.Add(1)
+			go func(name, sql string) {
+				defer wg.Done()
+				g, err := NewGrammar(name)
+				assert.NoError(t, err)
+
+				s, _, err := Build(shared, g)
+				assert.NoError(t, err)
+				assert.Equal(t, sql, s)
+
+				b := new(WhereBuilder).Where("name", "=", "test")
+				q := Query("SELECT id FROM table WHERE %s LIMIT %p", b, 10).Grammar(g)
+				assert.Equal(t, sql, q.String())
+				assert.Equal(t, sql, q.String())
+				assert.Equal(t, sql, cached[name].String())
+
+				_ = shared.String()
+				RegisterGrammar("concurrency", MysqlGrammar)
+			}(name, sql)
+		}
+	}
+	wg.Wait()
+}
+
 func BenchmarkBuilder_QueryString(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		var b = new(WhereBuilder).
@@ -71,3 +291,123 @@ func BenchmarkBuilder_QueryParams(b *testing.B) {
 		_ = Query(`SELECT "name" FROM "table" WHERE %s LIMIT %p`, b, 10).Params()
 	}
 }
+
+func TestQueryNamed(t *testing.T) {
+	w := new(WhereBuilder).Where("status", "=", "active")
+	q := QueryNamed("SELECT id FROM table WHERE %(where)s AND (owner = %(user)p OR editor = %(user)p) LIMIT %(limit)p", map[string]interface{}{
+		"where": w,
+		"user":  10,
+		"limit": 5,
+		"extra": "unused",
+	})
+	assert.Equal(t, `SELECT id FROM table WHERE "status" = $1 AND (owner = $2 OR editor = $2) LIMIT $3`, q.String())
+	assert.Equal(t, []interface{}{"active", 10, 5}, q.Params())
+
+	q.Grammar(MysqlGrammar())
+	assert.Equal(t, "SELECT id FROM table WHERE `status` = ? AND (owner = ? OR editor = ?) LIMIT ?", q.String())
+	assert.Equal(t, []interface{}{"active", 10, 10, 5}, q.Params())
+}
+
+func TestQueryNamedStruct(t *testing.T) {
+	type params struct {
+		Table  string `db:"table"`
+		UserID int    `db:"user_id"`
+		Since  string
+	}
+	q := QueryNamed("SELECT * FROM %(table)s WHERE user_id = %(user_id)p AND created_at > %(since)p OR parent_id = %(user_id)p", &params{
+		Table:  "orders",
+		UserID: 7,
+		Since:  "2019-01-01",
+	})
+	assert.Equal(t, `SELECT * FROM orders WHERE user_id = $1 AND created_at > $2 OR parent_id = $1`, q.String())
+	assert.Equal(t, []interface{}{7, "2019-01-01"}, q.Params())
+
+	q = QueryNamed("SELECT %(a)p, %(a)p", map[string]int{"a": 1})
+	assert.Equal(t, `SELECT $1, $1`, q.String())
+	assert.Equal(t, []interface{}{1}, q.Params())
+}
+
+func TestQueryNamedNested(t *testing.T) {
+	w := new(WhereBuilder).
+		Where("id", ">", 1).
+		WhereRaw("%s", QueryNamed("(a = %(x)p OR b = %(x)p OR c = %(now)p)", map[string]interface{}{"x": 2, "now": Raw("NOW()")}))
+	q := Query("SELECT * FROM t WHERE %s AND d = %p", w, 3)
+	assert.Equal(t, `SELECT * FROM t WHERE "id" > $1 AND (a = $2 OR b = $2 OR c = NOW()) AND d = $3`, q.String())
+	assert.Equal(t, []interface{}{1, 2, 3}, q.Params())
+}
+
+func TestQueryNamedError(t *testing.T) {
+	_, _, err := QueryNamed("SELECT %(a)p, %(b)p", map[string]interface{}{"a": 1}).Build()
+	assert.Equal(t, &FormatError{Query: "SELECT %(a)p, %(b)p", Pos: 14, Err: ErrMissingParam}, err)
+
+	_, _, err = QueryNamed("SELECT %(a)x", map[string]interface{}{"a": 1}).Build()
+	assert.ErrorIs(t, err, ErrUnknownVerb)
+
+	_, _, err = QueryNamed("SELECT %(a", map[string]interface{}{"a": 1}).Build()
+	assert.ErrorIs(t, err, ErrUnknownVerb)
+
+	var m map[string]interface{}
+	_, _, err = QueryNamed("SELECT %(a)p", m).Build()
+	assert.Equal(t, &FormatError{Query: "SELECT %(a)p", Pos: 7, Err: ErrMissingParam}, err)
+
+	_, _, err = QueryNamed("SELECT %(a)p", 1).Build()
+	assert.Equal(t, ErrNamedParams, err)
+
+	_, _, err = QueryNamed("SELECT %p", map[string]interface{}{}).Build()
+	assert.ErrorIs(t, err, ErrTooFewParams)
+
+	q, _, err := QueryNamed("SELECT '%%(a)p'", map[string]interface{}{}).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT '%(a)p'`, q)
+
+	q, _, err = Query("SELECT '%(a)p'").Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT '%(a)p'`, q)
+}
+
+func TestQueryIdentifierVerb(t *testing.T) {
+	q := Query(
+		"SELECT %i, %%i FROM %i WHERE %i = %p ORDER BY %i",
+		[]string{"id", "u.name", "o.*"}, "public.users", `my"col`, 1, "created_at",
+	)
+	assert.Equal(t, `SELECT "id", "u"."name", "o".*, %i FROM "public"."users" WHERE "my""col" = $1 ORDER BY "created_at"`, q.String())
+	assert.Equal(t, []interface{}{1}, q.Params())
+
+	q.Grammar(MysqlGrammar())
+	assert.Equal(t, "SELECT `id`, `u`.`name`, `o`.*, %i FROM `public`.`users` WHERE `my\"col` = ? ORDER BY `created_at`", q.String())
+
+	_, _, err := Query("SELECT %i", 1).Build()
+	assert.Equal(t, &FormatError{Query: "SELECT %i", Pos: 7, Err: ErrInvalidIdentifier}, err)
+
+	_, _, err = Query("SELECT %i", []string{}).Build()
+	assert.ErrorIs(t, err, ErrEmptyList)
+
+	_, _, err = Build(Query("SELECT %i FROM t", "a;b"), StrictGrammar(PgsqlGrammar(), IdentifierPattern))
+	assert.ErrorIs(t, err, ErrInvalidIdentifier)
+}
+
+func TestQueryListVerb(t *testing.T) {
+	q := Query(
+		"SELECT id FROM table WHERE id IN (%l) AND name IN (%l) AND type = %l AND %%l",
+		[]int{1, 2, 3}, []interface{}{"a", Raw("LOWER(%p)", "B")}, "car",
+	)
+	assert.Equal(t, `SELECT id FROM table WHERE id IN ($1, $2, $3) AND name IN ($4, LOWER($5)) AND type = $6 AND %l`, q.String())
+	assert.Equal(t, []interface{}{1, 2, 3, "a", "B", "car"}, q.Params())
+
+	q.Grammar(SQLiteGrammar())
+	assert.Equal(t, `SELECT id FROM table WHERE id IN (?, ?, ?) AND name IN (?, LOWER(?)) AND type = ? AND %l`, q.String())
+
+	q = Query("SELECT %l", []byte("data"))
+	assert.Equal(t, `SELECT $1`, q.String())
+	assert.Equal(t, []interface{}{[]byte("data")}, q.Params())
+
+	_, _, err := Query("SELECT id FROM table WHERE id IN (%l)", []int{}).Build()
+	assert.Equal(t, &FormatError{Query: "SELECT id FROM table WHERE id IN (%l)", Pos: 34, Err: ErrEmptyList}, err)
+
+	q = QueryNamed("SELECT %(cols)i FROM t WHERE id IN (%(ids)l) OR parent_id IN (%(ids)l)", map[string]interface{}{
+		"cols": []string{"id", "name"},
+		"ids":  []int{1, 2},
+	})
+	assert.Equal(t, `SELECT "id", "name" FROM t WHERE id IN ($1, $2) OR parent_id IN ($3, $4)`, q.String())
+	assert.Equal(t, []interface{}{1, 2, 1, 2}, q.Params())
+}
@@ -0,0 +1,352 @@
+package qb
+
+import "strings"
+
+// SelectBuilder builds SELECT queries
+//  var w = new(qb.WhereBuilder).Where("status", "=", "active")
+//  var b = new(qb.SelectBuilder).
+//    Select("id", "name").
+//    From("users").
+//    Where(w).
+//    OrderBy("name").
+//    Limit(10)
+//  _ = b.String() // SELECT "id", "name" FROM "users" WHERE "status" = $1 ORDER BY "name" ASC LIMIT $2
+//  _ = b.Params() // ["active", 10]
+type SelectBuilder struct {
+	columns []func(s *state) (string, []interface{}, error)
+	from    string
+	joins   []join
+	where   *WhereBuilder
+	groupBy []string
+	having  *WhereBuilder
+	order   *OrderBuilder
+	limit   LimitBuilder
+	grammar Grammar
+}
+
+// Select adds columns to the select list, an alias follows AS in any case.
+// Unlike tables, an alias without AS isn't recognized, since a typecast may contain spaces
+//  var b = new(qb.SelectBuilder).Select("id", "users.name AS n").From("users")
+//  _ = b.String() // SELECT "id", "users"."name" AS "n" FROM "users"
+func (b *SelectBuilder) Select(columns ...string) *SelectBuilder {
+	for _, column := range columns {
+		column := column
+		b.columns = append(b.columns, func(s *state) (string, []interface{}, error) {
+			return wrapSelect(s, column), nil, nil
+		})
+	}
+	return b
+}
+
+// SelectRaw adds an expression to the select list
+//  var b = new(qb.SelectBuilder).SelectRaw("COUNT(*)").From("users")
+//  _ = b.String() // SELECT COUNT(*) FROM "users"
+func (b *SelectBuilder) SelectRaw(query string, params ...interface{}) *SelectBuilder {
+	var f = &format{
+		query:  query,
+		params: params,
+	}
+	b.columns = append(b.columns, func(s *state) (string, []interface{}, error) {
+		return f.build(s)
+	})
+	return b
+}
+
+// From sets a table, it may have an alias
+//  var b = new(qb.SelectBuilder).From("public.users AS u")
+//  _ = b.String() // SELECT * FROM "public"."users" AS "u"
+func (b *SelectBuilder) From(table string) *SelectBuilder {
+	b.from = table
+	return b
+}
+
+// Join adds INNER JOIN
+//  var on = new(qb.WhereBuilder).WhereColumn("o.user_id", "=", "u.id").Where("o.status", "=", "paid")
+//  var b = new(qb.SelectBuilder).Select("u.id").From("users u").Join("orders o", on)
+//  _ = b.String() // SELECT "u"."id" FROM "users" AS "u" INNER JOIN "orders" AS "o" ON "o"."user_id" = "u"."id" AND "o"."status" = $1
+//  _ = b.Params() // ["paid"]
+func (b *SelectBuilder) Join(table string, on *WhereBuilder) *SelectBuilder {
+	b.joins = append(b.joins, join{"INNER JOIN", table, on})
+	return b
+}
+
+// LeftJoin adds LEFT JOIN
+func (b *SelectBuilder) LeftJoin(table string, on *WhereBuilder) *SelectBuilder {
+	b.joins = append(b.joins, join{"LEFT JOIN", table, on})
+	return b
+}
+
+// RightJoin adds RIGHT JOIN
+func (b *SelectBuilder) RightJoin(table string, on *WhereBuilder) *SelectBuilder {
+	b.joins = append(b.joins, join{"RIGHT JOIN", table, on})
+	return b
+}
+
+// FullJoin adds FULL JOIN
+func (b *SelectBuilder) FullJoin(table string, on *WhereBuilder) *SelectBuilder {
+	b.joins = append(b.joins, join{"FULL JOIN", table, on})
+	return b
+}
+
+// CrossJoin adds CROSS JOIN
+func (b *SelectBuilder) CrossJoin(table string) *SelectBuilder {
+	b.joins = append(b.joins, join{"CROSS JOIN", table, nil})
+	return b
+}
+
+// Where sets WHERE expressions
+func (b *SelectBuilder) Where(where *WhereBuilder) *SelectBuilder {
+	b.where = where
+	return b
+}
+
+// GroupBy adds columns to the GROUP BY list
+func (b *SelectBuilder) GroupBy(columns ...string) *SelectBuilder {
+	b.groupBy = append(b.groupBy, columns...)
+	return b
+}
+
+// Having sets HAVING expressions
+//  var h = new(qb.WhereBuilder).WhereRaw("COUNT(*) > %p", 1)
+//  var b = new(qb.SelectBuilder).Select("status").From("users").GroupBy("status").Having(h)
+//  _ = b.String() // SELECT "status" FROM "users" GROUP BY "status" HAVING COUNT(*) > $1
+func (b *SelectBuilder) Having(having *WhereBuilder) *SelectBuilder {
+	b.having = having
+	return b
+}
+
+// OrderBy adds columns to the ORDER BY list in ascending order
+func (b *SelectBuilder) OrderBy(columns ...string) *SelectBuilder {
+	for _, column := range columns {
+		b.orderBuilder().Order(column, "ASC")
+	}
+	return b
+}
+
+// OrderByDesc adds columns to the ORDER BY list in descending order
+func (b *SelectBuilder) OrderByDesc(columns ...string) *SelectBuilder {
+	for _, column := range columns {
+		b.orderBuilder().Order(column, "DESC")
+	}
+	return b
+}
+
+// Order sets ORDER BY expressions
+//  var o = new(qb.OrderBuilder).OrderNulls("deleted_at", "desc", "last")
+//  var b = new(qb.SelectBuilder).From("users").Order(o)
+//  _ = b.String() // SELECT * FROM "users" ORDER BY "deleted_at" DESC NULLS LAST
+func (b *SelectBuilder) Order(order *OrderBuilder) *SelectBuilder {
+	b.order = order
+	return b
+}
+
+// Limit sets LIMIT
+func (b *SelectBuilder) Limit(limit int) *SelectBuilder {
+	b.limit.Limit(limit)
+	return b
+}
+
+// Offset sets OFFSET
+func (b *SelectBuilder) Offset(offset int) *SelectBuilder {
+	b.limit.Offset(offset)
+	return b
+}
+
+// Page sets LIMIT and OFFSET of the page, pages start from 1
+func (b *SelectBuilder) Page(page, size int) *SelectBuilder {
+	b.limit.Page(page, size)
+	return b
+}
+
+// String implementations Stringer interface
+func (b *SelectBuilder) String() string {
+	s, _, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Params returns parameters for query
+func (b *SelectBuilder) Params() []interface{} {
+	_, params, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// Build returns the sql query string and parameters for query
+func (b *SelectBuilder) Build() (string, []interface{}, error) {
+	return render(b, b.g())
+}
+
+func (b *SelectBuilder) build(s *state) (string, []interface{}, error) {
+	var (
+		w      strings.Builder
+		params []interface{}
+	)
+
+	w.WriteString("SELECT ")
+	if len(b.columns) == 0 {
+		w.WriteString("*")
+	}
+	for i, f := range b.columns {
+		q, args, err := f(s)
+		if err != nil {
+			return "", nil, err
+		}
+		if i > 0 {
+			w.WriteString(", ")
+		}
+		w.WriteString(q)
+		params = append(params, args...)
+	}
+
+	if len(b.from) > 0 {
+		w.WriteString(" FROM ")
+		w.WriteString(wrapTable(s, b.from))
+	}
+
+	for _, j := range b.joins {
+		w.WriteString(" " + j.kind + " ")
+		w.WriteString(wrapTable(s, j.table))
+		if j.on == nil {
+			if j.kind == "CROSS JOIN" {
+				continue
+			}
+			return "", nil, ErrNoJoinCondition
+		}
+		q, args, err := s.build(j.on)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(q) == 0 {
+			return "", nil, ErrNoJoinCondition
+		}
+		w.WriteString(" ON ")
+		w.WriteString(q)
+		params = append(params, args...)
+	}
+
+	if b.where != nil {
+		q, args, err := s.build(b.where)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(q) > 0 {
+			w.WriteString(" WHERE ")
+			w.WriteString(q)
+			params = append(params, args...)
+		}
+	}
+
+	if len(b.groupBy) > 0 {
+		w.WriteString(" GROUP BY ")
+		for i, column := range b.groupBy {
+			if i > 0 {
+				w.WriteString(", ")
+			}
+			w.WriteString(s.wrap(column))
+		}
+	}
+
+	if b.having != nil {
+		q, args, err := s.build(b.having)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(q) > 0 {
+			w.WriteString(" HAVING ")
+			w.WriteString(q)
+			params = append(params, args...)
+		}
+	}
+
+	if b.order != nil && len(b.order.groups) > 0 {
+		q, _, err := s.build(b.order)
+		if err != nil {
+			return "", nil, err
+		}
+		w.WriteString(" ORDER BY ")
+		w.WriteString(q)
+	}
+
+	q, args, err := b.limit.build(s)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(q) > 0 {
+		w.WriteString(" " + q)
+		params = append(params, args...)
+	}
+
+	return w.String(), params, nil
+}
+
+// Grammar sets a Grammar
+func (b *SelectBuilder) Grammar(grammar Grammar) Builder {
+	b.grammar = grammar
+	return b
+}
+
+func (b *SelectBuilder) orderBuilder() *OrderBuilder {
+	if b.order == nil {
+		b.order = new(OrderBuilder)
+	}
+	return b.order
+}
+
+func (b *SelectBuilder) g() Grammar {
+	if b.grammar == nil {
+		return defaultGrammar()
+	}
+	return b.grammar
+}
+
+// wrapColumn wraps a column in quotes, a star is kept as is
+func wrapColumn(s *state, column string) string {
+	if column == "*" {
+		return column
+	}
+	if strings.HasSuffix(column, ".*") {
+		return s.wrap(column[:len(column)-2]) + ".*"
+	}
+	return s.wrap(column)
+}
+
+// wrapSelect wraps a column of the select list and its alias in quotes
+//  "u.name AS n" -> "u"."name" AS "n"
+func wrapSelect(s *state, column string) string {
+	for i := len(column) - 4; i > 0; i-- {
+		if strings.EqualFold(column[i:i+4], " AS ") {
+			var name, alias = strings.TrimSpace(column[:i]), strings.TrimSpace(column[i+4:])
+			if len(name) == 0 || len(alias) == 0 {
+				break
+			}
+			return wrapColumn(s, name) + " AS " + s.wrap(alias)
+		}
+	}
+	return wrapColumn(s, column)
+}
+
+// wrapTable wraps a table and its alias in quotes
+//  "public.users AS u", "public.users u" -> "public"."users" AS "u"
+func wrapTable(s *state, table string) string {
+	var i = strings.LastIndexByte(table, ' ')
+	if i < 0 {
+		return s.wrap(table)
+	}
+	var name, alias = strings.TrimSpace(table[:i]), table[i+1:]
+	if n := len(name); n > 3 && strings.EqualFold(name[n-3:], " AS") {
+		name = strings.TrimSpace(name[:n-3])
+	}
+	return s.wrap(name) + " AS " + s.wrap(alias)
+}
+
+// join is a JOIN expression
+type join struct {
+	kind  string
+	table string
+	on    *WhereBuilder
+}
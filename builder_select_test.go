@@ -0,0 +1,164 @@
+package qb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelect(t *testing.T) {
+	b := new(SelectBuilder).From("users")
+	assert.Equal(t, `SELECT * FROM "users"`, b.String())
+	assert.Nil(t, b.Params())
+}
+
+func TestSelectAlias(t *testing.T) {
+	b := new(SelectBuilder).
+		Select("u.name AS n", "price::numeric(10, 2) as p", "created_at::timestamp with time zone", "u.*", "name n").
+		From("users u")
+
+	assert.Equal(t, `SELECT "u"."name" AS "n", "price"::numeric(10, 2) AS "p", "created_at"::timestamp with time zone, "u".*, "name n" FROM "users" AS "u"`, b.String())
+
+	b.Grammar(MysqlGrammar())
+	assert.Equal(t, "SELECT `u`.`name` AS `n`, `price::numeric(10, 2)` AS `p`, `created_at::timestamp with time zone`, `u`.*, `name n` FROM `users` AS `u`", b.String())
+}
+
+func TestSelectFull(t *testing.T) {
+	w := new(WhereBuilder).
+		Where("status", "=", "active").
+		WhereIn("type", "a", "b")
+	h := new(WhereBuilder).
+		WhereRaw("COUNT(*) > %p", 1)
+	b := new(SelectBuilder).
+		Select("type", "u.*").
+		SelectRaw("COUNT(*) AS %s", "total").
+		From("public.users").
+		Where(w).
+		GroupBy("type").
+		Having(h).
+		OrderBy("type").
+		OrderByDesc("total").
+		Limit(10).
+		Offset(20)
+
+	assert.Equal(t, `SELECT "type", "u".*, COUNT(*) AS total FROM "public"."users" WHERE "status" = $1 AND "type" IN ($2, $3) GROUP BY "type" HAVING COUNT(*) > $4 ORDER BY "type" ASC, "total" DESC LIMIT $5 OFFSET $6`, b.String())
+	assert.Equal(t, []interface{}{"active", "a", "b", 1, 10, 20}, b.Params())
+}
+
+func TestSelectEmptyWhere(t *testing.T) {
+	b := new(SelectBuilder).
+		Select("id").
+		From("users").
+		Where(new(WhereBuilder))
+	assert.Equal(t, `SELECT "id" FROM "users"`, b.String())
+}
+
+func TestSelectMySQLGrammar(t *testing.T) {
+	w := new(WhereBuilder).Where("status", "=", "active")
+	b := new(SelectBuilder).
+		Select("id", "name").
+		From("users").
+		Where(w).
+		Limit(10).
+		Grammar(MysqlGrammar())
+
+	assert.Equal(t, "SELECT `id`, `name` FROM `users` WHERE `status` = ? LIMIT ?", b.String())
+	assert.Equal(t, []interface{}{"active", 10}, b.Params())
+}
+
+func TestSelectSub(t *testing.T) {
+	sub := new(SelectBuilder).
+		Select("user_id").
+		From("orders").
+		Where(new(WhereBuilder).Where("total", ">", 100))
+	w := new(WhereBuilder).
+		Where("status", "=", "active").
+		WhereInSub("id", sub)
+	b := new(SelectBuilder).
+		Select("id").
+		From("users").
+		Where(w).
+		Limit(5)
+
+	assert.Equal(t, `SELECT "id" FROM "users" WHERE "status" = $1 AND "id" IN (SELECT "user_id" FROM "orders" WHERE "total" > $2) LIMIT $3`, b.String())
+	assert.Equal(t, []interface{}{"active", 100, 5}, b.Params())
+}
+
+func TestSelectBuildError(t *testing.T) {
+	_, _, err := new(SelectBuilder).SelectRaw("COUNT(%s)").From("users").Build()
+	assert.Error(t, err)
+}
+
+func TestSelectJoin(t *testing.T) {
+	on := new(WhereBuilder).
+		WhereColumn("o.user_id", "=", "u.id").
+		Where("o.status", "=", "paid")
+	w := new(WhereBuilder).
+		Where("u.status", "=", "active")
+	b := new(SelectBuilder).
+		Select("u.id", "o.*").
+		From("public.users AS u").
+		Join("public.orders o", on).
+		LeftJoin("profiles p", new(WhereBuilder).WhereColumn("p.user_id", "=", "u.id")).
+		RightJoin("roles", new(WhereBuilder).WhereColumn("roles.id", "=", "u.role_id")).
+		FullJoin("teams t", new(WhereBuilder).WhereColumn("t.id", "=", "u.team_id")).
+		CrossJoin("settings").
+		Where(w).
+		Limit(10)
+
+	assert.Equal(t, `SELECT "u"."id", "o".* FROM "public"."users" AS "u"`+
+		` INNER JOIN "public"."orders" AS "o" ON "o"."user_id" = "u"."id" AND "o"."status" = $1`+
+		` LEFT JOIN "profiles" AS "p" ON "p"."user_id" = "u"."id"`+
+		` RIGHT JOIN "roles" ON "roles"."id" = "u"."role_id"`+
+		` FULL JOIN "teams" AS "t" ON "t"."id" = "u"."team_id"`+
+		` CROSS JOIN "settings"`+
+		` WHERE "u"."status" = $2 LIMIT $3`, b.String())
+	assert.Equal(t, []interface{}{"paid", "active", 10}, b.Params())
+}
+
+func TestSelectJoinMySQLGrammar(t *testing.T) {
+	on := new(WhereBuilder).WhereColumn("o.user_id", "=", "u.id")
+	b := new(SelectBuilder).
+		Select("u.id").
+		From("users u").
+		Join("orders o", on).
+		Grammar(MysqlGrammar())
+
+	assert.Equal(t, "SELECT `u`.`id` FROM `users` AS `u` INNER JOIN `orders` AS `o` ON `o`.`user_id` = `u`.`id`", b.String())
+}
+
+func TestSelectJoinError(t *testing.T) {
+	_, _, err := new(SelectBuilder).From("users").Join("orders", nil).Build()
+	assert.Equal(t, ErrNoJoinCondition, err)
+}
+
+func TestSelectOrder(t *testing.T) {
+	o := new(OrderBuilder).
+		OrderNulls("deleted_at", "desc", "last").
+		Order("id", "asc")
+	b := new(SelectBuilder).
+		From("users").
+		Order(o).
+		Limit(10)
+
+	assert.Equal(t, `SELECT * FROM "users" ORDER BY "deleted_at" DESC NULLS LAST, "id" ASC LIMIT $1`, b.String())
+
+	b.Grammar(MysqlGrammar())
+	assert.Equal(t, "SELECT * FROM `users` ORDER BY ISNULL(`deleted_at`) ASC, `deleted_at` DESC, `id` ASC LIMIT ?", b.String())
+}
+
+func TestSelectPage(t *testing.T) {
+	b := new(SelectBuilder).
+		From("users").
+		OrderBy("id").
+		Page(2, 10)
+
+	assert.Equal(t, `SELECT * FROM "users" ORDER BY "id" ASC LIMIT $1 OFFSET $2`, b.String())
+	assert.Equal(t, []interface{}{10, 10}, b.Params())
+
+	q := new(SelectBuilder).
+		From("users").
+		Offset(10).
+		Grammar(MysqlGrammar())
+	assert.Equal(t, "SELECT * FROM `users` LIMIT 18446744073709551615 OFFSET ?", q.String())
+}
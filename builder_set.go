@@ -6,57 +6,107 @@ import (
 
 // SetBuilder builds SET expressions
 type SetBuilder struct {
-	groups  []func() string
-	params  []interface{}
+	groups  []func(s *state) (string, []interface{}, error)
 	grammar Grammar
-	regular bool
 }
 
-// Set adds a new SET expression
-//  var b = new(qb.SetBuilder).Set("name", "Tom").Set("surname", "Johnson")
-//  _ = b.String() // "name" = $1, "surname" = $2
-//  _ = b.Params() // ["Tom", "Johnson"]
+// Set adds a new SET expression, the value may be an expression, see Expr
+//  var b = new(qb.SetBuilder).Set("name", "Tom").Set("updated_at", qb.Raw("NOW()"))
+//  _ = b.String() // "name" = $1, "updated_at" = NOW()
+//  _ = b.Params() // ["Tom"]
 func (b *SetBuilder) Set(field string, value interface{}) *SetBuilder {
-	b.params = append(b.params, value)
-	b.groups = append(b.groups, func() string {
-		return ", " + b.g().Wrap(field) + " = " + b.g().Placeholder(1)
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		v, params, err := s.value(value)
+		return ", " + s.wrap(field) + " = " + v, params, err
 	})
 	return b
 }
 
+// SetStruct adds SET expressions for fields of a struct with db tags.
+// Fields tagged "-" or "readonly" are skipped, "omitempty" fields are skipped if they are empty.
+//  type User struct {
+//    ID    int    `db:"id,readonly"`
+//    Name  string `db:"name"`
+//    Email string `db:"email,omitempty"`
+//  }
+//  var b = new(qb.SetBuilder).SetStruct(User{ID: 1, Name: "Tom"})
+//  _ = b.String() // "name" = $1
+//  _ = b.Params() // ["Tom"]
+func (b *SetBuilder) SetStruct(v interface{}) *SetBuilder {
+	rv, err := structValue(v)
+	if err != nil {
+		b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+			return "", nil, err
+		})
+		return b
+	}
+	for _, f := range structFields(rv.Type()) {
+		if f.readonly {
+			continue
+		}
+		if fv, ok := f.value(rv); ok && !f.empty(fv) {
+			b.Set(f.column, fv.Interface())
+		}
+	}
+	return b
+}
+
 // SetRaw adds a new SET expression
 //  var b = new(qb.SetBuilder).SetRaw("jsondata->'name' = %p", "Tom")
 //  _ = b.String() // jsondata->'name' = $1
 //  _ = b.Params() // ["Tom"]
 func (b *SetBuilder) SetRaw(query string, params ...interface{}) *SetBuilder {
 	var f = &format{
-		query:   query,
-		params:  params,
-		grammar: b.g(),
+		query:  query,
+		params: params,
 	}
-	b.params = append(b.params, f.Params()...)
-	b.groups = append(b.groups, func() string {
-		return ", " + f.Grammar(b.g()).String()
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		q, params, err := f.build(s)
+		return ", " + q, params, err
 	})
 	return b
 }
 
 // String implementations Stringer interface
 func (b *SetBuilder) String() string {
-	if len(b.groups) == 0 {
-		return ""
+	s, _, err := b.Build()
+	if err != nil {
+		panic(err)
 	}
-	defer b.r()
-	var s strings.Builder
-	for _, f := range b.groups {
-		s.WriteString(f())
-	}
-	return s.String()[2:]
+	return s
 }
 
 // Params returns parameters for query
 func (b *SetBuilder) Params() []interface{} {
-	return b.params
+	_, params, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// Build returns the sql expression and parameters for query
+func (b *SetBuilder) Build() (string, []interface{}, error) {
+	return render(b, b.g())
+}
+
+func (b *SetBuilder) build(s *state) (string, []interface{}, error) {
+	if len(b.groups) == 0 {
+		return "", nil, nil
+	}
+	var (
+		w      strings.Builder
+		params []interface{}
+	)
+	for _, f := range b.groups {
+		q, args, err := f(s)
+		if err != nil {
+			return "", nil, err
+		}
+		w.WriteString(q)
+		params = append(params, args...)
+	}
+	return w.String()[2:], params, nil
 }
 
 // Grammar sets a Grammar
@@ -67,13 +117,7 @@ func (b *SetBuilder) Grammar(grammar Grammar) Builder {
 
 func (b *SetBuilder) g() Grammar {
 	if b.grammar == nil {
-		b.grammar = grammar()
+		return defaultGrammar()
 	}
 	return b.grammar
 }
-
-func (b *SetBuilder) r() {
-	if !b.regular {
-		b.grammar = grammar()
-	}
-}
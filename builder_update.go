@@ -0,0 +1,115 @@
+package qb
+
+import "strings"
+
+// UpdateBuilder builds UPDATE queries
+//  var s = new(qb.SetBuilder).Set("name", "Marty")
+//  var w = new(qb.WhereBuilder).Where("id", "=", 1)
+//  var b = new(qb.UpdateBuilder).Table("users").Set(s).Where(w)
+//  _ = b.String() // UPDATE "users" SET "name" = $1 WHERE "id" = $2
+//  _ = b.Params() // ["Marty", 1]
+type UpdateBuilder struct {
+	table   string
+	set     *SetBuilder
+	where   *WhereBuilder
+	all     bool
+	grammar Grammar
+}
+
+// Table sets a table
+func (b *UpdateBuilder) Table(table string) *UpdateBuilder {
+	b.table = table
+	return b
+}
+
+// Set sets SET expressions
+func (b *UpdateBuilder) Set(set *SetBuilder) *UpdateBuilder {
+	b.set = set
+	return b
+}
+
+// Where sets WHERE expressions
+func (b *UpdateBuilder) Where(where *WhereBuilder) *UpdateBuilder {
+	b.where = where
+	return b
+}
+
+// All allows the query to update all rows of the table without WHERE expressions
+//  var s = new(qb.SetBuilder).Set("status", "archived")
+//  var b = new(qb.UpdateBuilder).Table("users").Set(s).All()
+//  _ = b.String() // UPDATE "users" SET "status" = $1
+func (b *UpdateBuilder) All() *UpdateBuilder {
+	b.all = true
+	return b
+}
+
+// String implementations Stringer interface
+func (b *UpdateBuilder) String() string {
+	s, _, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Params returns parameters for query
+func (b *UpdateBuilder) Params() []interface{} {
+	_, params, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// Build returns the sql query string and parameters for query.
+// It returns ErrNoWhere if WHERE renders no expressions and All wasn't called.
+func (b *UpdateBuilder) Build() (string, []interface{}, error) {
+	return render(b, b.g())
+}
+
+func (b *UpdateBuilder) build(s *state) (string, []interface{}, error) {
+	if b.set == nil || len(b.set.groups) == 0 {
+		return "", nil, ErrNoSet
+	}
+	var w strings.Builder
+	w.WriteString("UPDATE ")
+	w.WriteString(s.wrap(b.table))
+
+	q, params, err := s.build(b.set)
+	if err != nil {
+		return "", nil, err
+	}
+	w.WriteString(" SET ")
+	w.WriteString(q)
+
+	var where bool
+	if b.where != nil {
+		q, args, err := s.build(b.where)
+		if err != nil {
+			return "", nil, err
+		}
+		if where = len(q) > 0; where {
+			w.WriteString(" WHERE ")
+			w.WriteString(q)
+			params = append(params, args...)
+		}
+	}
+	if !where && !b.all {
+		return "", nil, ErrNoWhere
+	}
+
+	return w.String(), params, nil
+}
+
+// Grammar sets a Grammar
+func (b *UpdateBuilder) Grammar(grammar Grammar) Builder {
+	b.grammar = grammar
+	return b
+}
+
+func (b *UpdateBuilder) g() Grammar {
+	if b.grammar == nil {
+		return defaultGrammar()
+	}
+	return b.grammar
+}
@@ -0,0 +1,48 @@
+package qb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdate(t *testing.T) {
+	s := new(SetBuilder).
+		Set("name", "Marty").
+		Set("surname", "McFly")
+	w := new(WhereBuilder).
+		Where("id", "=", 10)
+	b := new(UpdateBuilder).
+		Table("users").
+		Set(s).
+		Where(w)
+
+	assert.Equal(t, `UPDATE "users" SET "name" = $1, "surname" = $2 WHERE "id" = $3`, b.String())
+	assert.Equal(t, []interface{}{"Marty", "McFly", 10}, b.Params())
+}
+
+func TestUpdateAll(t *testing.T) {
+	s := new(SetBuilder).Set("status", "archived")
+	b := new(UpdateBuilder).
+		Table("users").
+		Set(s).
+		All().
+		Grammar(MysqlGrammar())
+
+	assert.Equal(t, "UPDATE `users` SET `status` = ?", b.String())
+	assert.Equal(t, []interface{}{"archived"}, b.Params())
+}
+
+func TestUpdateErrors(t *testing.T) {
+	s := new(SetBuilder).Set("status", "archived")
+
+	_, _, err := new(UpdateBuilder).Table("users").Set(s).Build()
+	assert.True(t, errors.Is(err, ErrNoWhere))
+
+	_, _, err = new(UpdateBuilder).Table("users").Set(s).Where(new(WhereBuilder)).Build()
+	assert.True(t, errors.Is(err, ErrNoWhere))
+
+	_, _, err = new(UpdateBuilder).Table("users").All().Build()
+	assert.True(t, errors.Is(err, ErrNoSet))
+}
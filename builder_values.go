@@ -4,10 +4,11 @@ import "strings"
 
 // ValuesBuilder builds VALUES expressions
 type ValuesBuilder struct {
-	groups  []func() string
-	params  []interface{}
+	groups  []func(s *state) (string, []interface{}, error)
+	rows    []int
+	columns []string
+	err     error // invalid struct added by ValuesStruct
 	grammar Grammar
-	regular bool
 }
 
 // Values sets values and adds a new VALUES expression
@@ -16,30 +17,120 @@ type ValuesBuilder struct {
 //    Values(2, "Emmett", "Brown")
 //  _ = b.String() // ($1, $2, $3), ($4, $5, $6)
 //  _ = b.Params() // [1, "Marty", "McFly", 2, "Emmett", "Brown"]
+//
+// Values may be expressions, see Expr
+//  var b = new(qb.ValuesBuilder).Values(qb.Default, "Marty", qb.Raw("NOW()"))
+//  _ = b.String() // (DEFAULT, $1, NOW())
+//  _ = b.Params() // ["Marty"]
 func (b *ValuesBuilder) Values(values ...interface{}) *ValuesBuilder {
-	b.params = append(b.params, values...)
-	b.groups = append(b.groups, func() string {
-		return ", (" + b.g().Placeholder(len(values)) + ")"
+	b.rows = append(b.rows, len(values))
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		p, params, err := s.values(values)
+		return ", (" + p + ")", params, err
 	})
 	return b
 }
 
+// ValuesStruct adds VALUES expressions for structs with db tags.
+// Columns are taken from the first struct, see Columns.
+// Fields tagged "-" or "readonly" are skipped, "omitempty" fields
+// are skipped if they are empty in the first struct.
+//  type User struct {
+//    ID    int    `db:"id,readonly"`
+//    Name  string `db:"name"`
+//    Email string `db:"email,omitempty"`
+//  }
+//  var b = new(qb.ValuesBuilder).ValuesStruct(User{Name: "Marty"}, User{Name: "Emmett"})
+//  _ = b.Columns() // ["name"]
+//  _ = b.String()  // ($1), ($2)
+//  _ = b.Params()  // ["Marty", "Emmett"]
+func (b *ValuesBuilder) ValuesStruct(v ...interface{}) *ValuesBuilder {
+	for _, x := range v {
+		rv, err := structValue(x)
+		if err != nil {
+			if b.err == nil {
+				b.err = err
+			}
+			return b
+		}
+		var (
+			fields = structFields(rv.Type())
+			values = make([]interface{}, 0, len(fields))
+		)
+		if b.columns == nil {
+			b.columns = make([]string, 0, len(fields))
+			for _, f := range fields {
+				if fv, ok := f.value(rv); !f.readonly && ok && !f.empty(fv) {
+					b.columns = append(b.columns, f.column)
+				}
+			}
+		}
+		for _, column := range b.columns {
+			for _, f := range fields {
+				if f.column == column {
+					var value interface{}
+					if fv, ok := f.value(rv); ok {
+						value = fv.Interface()
+					}
+					values = append(values, value)
+					break
+				}
+			}
+		}
+		b.Values(values...)
+	}
+	return b
+}
+
+// Columns returns columns of structs added by ValuesStruct,
+// they follow the order of the values
+func (b *ValuesBuilder) Columns() []string {
+	return b.columns
+}
+
 // String implementations Stringer interface
 func (b *ValuesBuilder) String() string {
-	if len(b.groups) == 0 {
-		return ""
-	}
-	defer b.r()
-	var s strings.Builder
-	for _, f := range b.groups {
-		s.WriteString(f())
+	s, _, err := b.Build()
+	if err != nil {
+		panic(err)
 	}
-	return s.String()[2:]
+	return s
 }
 
 // Params returns parameters for query
 func (b *ValuesBuilder) Params() []interface{} {
-	return b.params
+	_, params, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// Build returns the sql expression and parameters for query
+func (b *ValuesBuilder) Build() (string, []interface{}, error) {
+	return render(b, b.g())
+}
+
+func (b *ValuesBuilder) build(s *state) (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+	if len(b.groups) == 0 {
+		return "", nil, nil
+	}
+	var (
+		w      strings.Builder
+		params []interface{}
+	)
+	for _, f := range b.groups {
+		q, args, err := f(s)
+		if err != nil {
+			return "", nil, err
+		}
+		w.WriteString(q)
+		params = append(params, args...)
+	}
+	return w.String()[2:], params, nil
 }
 
 // Grammar sets a Grammar
@@ -50,13 +141,7 @@ func (b *ValuesBuilder) Grammar(grammar Grammar) Builder {
 
 func (b *ValuesBuilder) g() Grammar {
 	if b.grammar == nil {
-		b.grammar = grammar()
+		return defaultGrammar()
 	}
 	return b.grammar
 }
-
-func (b *ValuesBuilder) r() {
-	if !b.regular {
-		b.grammar = grammar()
-	}
-}
@@ -1,24 +1,44 @@
 package qb
 
-import "strings"
+import (
+	"sort"
+	"strings"
+)
+
+// RowValuesGrammar is implemented by grammars which support row values comparison,
+// e.g. ("a", "b") > ($1, $2)
+type RowValuesGrammar interface {
+	Grammar
+	RowValues() bool
+}
+
+// ILikeGrammar is implemented by grammars which support case-insensitive ILIKE,
+// other grammars compare values in lower case
+type ILikeGrammar interface {
+	Grammar
+	ILike() bool
+}
 
 // WhereBuilder builds WHERE expressions.
 type WhereBuilder struct {
-	groups  []func() string
-	params  []interface{}
+	groups  []func(s *state) (string, []interface{}, error)
 	grammar Grammar
-	regular bool
 }
 
-// Where adds an expression to the group
+// Where adds an expression to the group,
+// the operator must be registered for the grammar, see RegisterOperator,
+// the value may be an expression or a subquery, see Expr
 //  var b = new(qb.WhereBuilder).Where("name", "=", "Tom")
 //  _ = b.String() // "name" = $1
 //  _ = b.Params() // ["Tom"]
 func (b *WhereBuilder) Where(field, operator string, value interface{}) *WhereBuilder {
 	boolean := b.and()
-	b.params = append(b.params, value)
-	b.groups = append(b.groups, func() string {
-		return boolean + b.g().Wrap(field) + " " + operator + " " + b.g().Placeholder(1)
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		if err := s.operator(operator); err != nil {
+			return "", nil, err
+		}
+		v, params, err := s.value(value)
+		return boolean + s.wrap(field) + " " + operator + " " + v, params, err
 	})
 	return b
 }
@@ -29,9 +49,12 @@ func (b *WhereBuilder) Where(field, operator string, value interface{}) *WhereBu
 //  _ = b.Params() // [1, 2]
 func (b *WhereBuilder) WhereOr(field, operator string, value interface{}) *WhereBuilder {
 	boolean := b.or()
-	b.params = append(b.params, value)
-	b.groups = append(b.groups, func() string {
-		return boolean + b.g().Wrap(field) + " " + operator + " " + b.g().Placeholder(1)
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		if err := s.operator(operator); err != nil {
+			return "", nil, err
+		}
+		v, params, err := s.value(value)
+		return boolean + s.wrap(field) + " " + operator + " " + v, params, err
 	})
 	return b
 }
@@ -46,11 +69,11 @@ func (b *WhereBuilder) WhereRaw(query string, params ...interface{}) *WhereBuild
 			query:  query,
 			params: params,
 		}
-		s = b.and()
+		boolean = b.and()
 	)
-	b.params = append(b.params, f.Params()...)
-	b.groups = append(b.groups, func() string {
-		return s + f.Grammar(b.g()).String()
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		q, params, err := f.build(s)
+		return boolean + q, params, err
 	})
 	return b
 }
@@ -65,26 +88,22 @@ func (b *WhereBuilder) WhereRawOr(query string, params ...interface{}) *WhereBui
 			query:  query,
 			params: params,
 		}
-		s = b.or()
+		boolean = b.or()
 	)
-	b.params = append(b.params, f.Params()...)
-	b.groups = append(b.groups, func() string {
-		return s + f.Grammar(b.g()).String()
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		q, params, err := f.build(s)
+		return boolean + q, params, err
 	})
 	return b
 }
 
-// WhereIn adds an expression to the group
+// WhereIn adds an expression to the group,
+// without values it's always false: 1 = 0
 //  var b = new(qb.WhereBuilder).WhereIn("id", 1, 2, 3)
 //  _ = b.String() // "id" IN ($1, $2, $3)
 //  _ = b.Params() // [1, 2, 3]
 func (b *WhereBuilder) WhereIn(field string, params ...interface{}) *WhereBuilder {
-	boolean := b.and()
-	b.params = append(b.params, params...)
-	b.groups = append(b.groups, func() string {
-		return boolean + b.g().Wrap(field) + " IN (" + b.g().Placeholder(len(params)) + ")"
-	})
-	return b
+	return b.whereIn(b.and(), field, false, params)
 }
 
 // WhereInOr adds an expression to the group
@@ -92,25 +111,16 @@ func (b *WhereBuilder) WhereIn(field string, params ...interface{}) *WhereBuilde
 //  _ = b.String() // "id" IN ($1, $2, $3)
 //  _ = b.Params() // [1, 2, 3]
 func (b *WhereBuilder) WhereInOr(field string, params ...interface{}) *WhereBuilder {
-	boolean := b.or()
-	b.params = append(b.params, params...)
-	b.groups = append(b.groups, func() string {
-		return boolean + b.g().Wrap(field) + " IN (" + b.g().Placeholder(len(params)) + ")"
-	})
-	return b
+	return b.whereIn(b.or(), field, false, params)
 }
 
-// WhereNotIn adds an expression to the group
+// WhereNotIn adds an expression to the group,
+// without values it's always true: 1 = 1
 //  var b = new(qb.WhereBuilder).WhereNotIn("id", 1, 2, 3)
 //  _ = b.String() // "id" NOT IN ($1, $2, $3)
 //  _ = b.Params() // [1, 2, 3]
 func (b *WhereBuilder) WhereNotIn(field string, params ...interface{}) *WhereBuilder {
-	boolean := b.and()
-	b.params = append(b.params, params...)
-	b.groups = append(b.groups, func() string {
-		return boolean + b.g().Wrap(field) + " NOT IN (" + b.g().Placeholder(len(params)) + ")"
-	})
-	return b
+	return b.whereIn(b.and(), field, true, params)
 }
 
 // WhereNotInOr adds an expression to the group
@@ -118,12 +128,43 @@ func (b *WhereBuilder) WhereNotIn(field string, params ...interface{}) *WhereBui
 //  _ = b.String() // "id" NOT IN ($1, $2, $3)
 //  _ = b.Params() // [1, 2, 3]
 func (b *WhereBuilder) WhereNotInOr(field string, params ...interface{}) *WhereBuilder {
-	boolean := b.or()
-	b.params = append(b.params, params...)
-	b.groups = append(b.groups, func() string {
-		return boolean + b.g().Wrap(field) + " NOT IN (" + b.g().Placeholder(len(params)) + ")"
-	})
-	return b
+	return b.whereIn(b.or(), field, true, params)
+}
+
+// WhereInSlice adds an expression to the group with values of any slice,
+// an empty slice is always false: 1 = 0, a value which isn't a slice is a single value
+//  var b = new(qb.WhereBuilder).WhereInSlice("id", []int64{1, 2, 3})
+//  _ = b.String() // "id" IN ($1, $2, $3)
+//  _ = b.Params() // [1, 2, 3]
+func (b *WhereBuilder) WhereInSlice(field string, slice interface{}) *WhereBuilder {
+	return b.whereIn(b.and(), field, false, sliceValues(slice))
+}
+
+// WhereInSliceOr adds an expression to the group with values of any slice,
+// an empty slice is always false: 1 = 0, a value which isn't a slice is a single value
+//  var b = new(qb.WhereBuilder).WhereInSliceOr("id", []int64{1, 2, 3})
+//  _ = b.String() // "id" IN ($1, $2, $3)
+//  _ = b.Params() // [1, 2, 3]
+func (b *WhereBuilder) WhereInSliceOr(field string, slice interface{}) *WhereBuilder {
+	return b.whereIn(b.or(), field, false, sliceValues(slice))
+}
+
+// WhereNotInSlice adds an expression to the group with values of any slice,
+// an empty slice is always true: 1 = 1, a value which isn't a slice is a single value
+//  var b = new(qb.WhereBuilder).WhereNotInSlice("id", []int64{1, 2, 3})
+//  _ = b.String() // "id" NOT IN ($1, $2, $3)
+//  _ = b.Params() // [1, 2, 3]
+func (b *WhereBuilder) WhereNotInSlice(field string, slice interface{}) *WhereBuilder {
+	return b.whereIn(b.and(), field, true, sliceValues(slice))
+}
+
+// WhereNotInSliceOr adds an expression to the group with values of any slice,
+// an empty slice is always true: 1 = 1, a value which isn't a slice is a single value
+//  var b = new(qb.WhereBuilder).WhereNotInSliceOr("id", []int64{1, 2, 3})
+//  _ = b.String() // "id" NOT IN ($1, $2, $3)
+//  _ = b.Params() // [1, 2, 3]
+func (b *WhereBuilder) WhereNotInSliceOr(field string, slice interface{}) *WhereBuilder {
+	return b.whereIn(b.or(), field, true, sliceValues(slice))
 }
 
 // WhereInSub adds an expression to the group
@@ -132,9 +173,9 @@ func (b *WhereBuilder) WhereNotInOr(field string, params ...interface{}) *WhereB
 //  _ = b.Params() // ["Tom"]
 func (b *WhereBuilder) WhereInSub(field string, query Builder) *WhereBuilder {
 	boolean := b.and()
-	b.params = append(b.params, query.Params()...)
-	b.groups = append(b.groups, func() string {
-		return boolean + b.g().Wrap(field) + " IN (" + query.Grammar(b.g()).String() + ")"
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		q, params, err := s.build(query)
+		return boolean + s.wrap(field) + " IN (" + q + ")", params, err
 	})
 	return b
 }
@@ -145,9 +186,9 @@ func (b *WhereBuilder) WhereInSub(field string, query Builder) *WhereBuilder {
 //  _ = b.Params() // ["Tom"]
 func (b *WhereBuilder) WhereInSubOr(field string, query Builder) *WhereBuilder {
 	boolean := b.or()
-	b.params = append(b.params, query.Params()...)
-	b.groups = append(b.groups, func() string {
-		return boolean + b.g().Wrap(field) + " IN (" + query.Grammar(b.g()).String() + ")"
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		q, params, err := s.build(query)
+		return boolean + s.wrap(field) + " IN (" + q + ")", params, err
 	})
 	return b
 }
@@ -158,9 +199,9 @@ func (b *WhereBuilder) WhereInSubOr(field string, query Builder) *WhereBuilder {
 //  _ = b.Params() // ["Tom"]
 func (b *WhereBuilder) WhereNotInSub(field string, query Builder) *WhereBuilder {
 	boolean := b.and()
-	b.params = append(b.params, query.Params()...)
-	b.groups = append(b.groups, func() string {
-		return boolean + b.g().Wrap(field) + " NOT IN (" + query.Grammar(b.g()).String() + ")"
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		q, params, err := s.build(query)
+		return boolean + s.wrap(field) + " NOT IN (" + q + ")", params, err
 	})
 	return b
 }
@@ -171,21 +212,113 @@ func (b *WhereBuilder) WhereNotInSub(field string, query Builder) *WhereBuilder
 //  _ = b.Params() // ["Tom"]
 func (b *WhereBuilder) WhereNotInSubOr(field string, query Builder) *WhereBuilder {
 	boolean := b.or()
-	b.params = append(b.params, query.Params()...)
-	b.groups = append(b.groups, func() string {
-		return boolean + b.g().Wrap(field) + " NOT IN (" + query.Grammar(b.g()).String() + ")"
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		q, params, err := s.build(query)
+		return boolean + s.wrap(field) + " NOT IN (" + q + ")", params, err
+	})
+	return b
+}
+
+// WhereExists adds an expression to the group,
+// the subquery may refer to columns of the outer query
+//  var on = new(qb.WhereBuilder).WhereColumn("o.user_id", "=", "u.id").Where("o.status", "=", "paid")
+//  var b = new(qb.WhereBuilder).Where("u.active", "=", true).WhereExists(
+//    new(qb.SelectBuilder).SelectRaw("1").From("orders o").Where(on))
+//  _ = b.String() // "u"."active" = $1 AND EXISTS (SELECT 1 FROM "orders" AS "o" WHERE "o"."user_id" = "u"."id" AND "o"."status" = $2)
+//  _ = b.Params() // [true, "paid"]
+func (b *WhereBuilder) WhereExists(query Builder) *WhereBuilder {
+	boolean := b.and()
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		q, params, err := s.build(query)
+		return boolean + "EXISTS (" + q + ")", params, err
+	})
+	return b
+}
+
+// WhereExistsOr adds an expression to the group,
+// the subquery may refer to columns of the outer query
+//  var on = new(qb.WhereBuilder).WhereColumn("o.user_id", "=", "u.id").Where("o.status", "=", "paid")
+//  var b = new(qb.WhereBuilder).Where("u.active", "=", true).WhereExistsOr(
+//    new(qb.SelectBuilder).SelectRaw("1").From("orders o").Where(on))
+//  _ = b.String() // "u"."active" = $1 OR EXISTS (SELECT 1 FROM "orders" AS "o" WHERE "o"."user_id" = "u"."id" AND "o"."status" = $2)
+//  _ = b.Params() // [true, "paid"]
+func (b *WhereBuilder) WhereExistsOr(query Builder) *WhereBuilder {
+	boolean := b.or()
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		q, params, err := s.build(query)
+		return boolean + "EXISTS (" + q + ")", params, err
 	})
 	return b
 }
 
+// WhereNotExists adds an expression to the group,
+// the subquery may refer to columns of the outer query
+//  var on = new(qb.WhereBuilder).WhereColumn("o.user_id", "=", "u.id").Where("o.status", "=", "paid")
+//  var b = new(qb.WhereBuilder).Where("u.active", "=", true).WhereNotExists(
+//    new(qb.SelectBuilder).SelectRaw("1").From("orders o").Where(on))
+//  _ = b.String() // "u"."active" = $1 AND NOT EXISTS (SELECT 1 FROM "orders" AS "o" WHERE "o"."user_id" = "u"."id" AND "o"."status" = $2)
+//  _ = b.Params() // [true, "paid"]
+func (b *WhereBuilder) WhereNotExists(query Builder) *WhereBuilder {
+	boolean := b.and()
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		q, params, err := s.build(query)
+		return boolean + "NOT EXISTS (" + q + ")", params, err
+	})
+	return b
+}
+
+// WhereNotExistsOr adds an expression to the group,
+// the subquery may refer to columns of the outer query
+//  var on = new(qb.WhereBuilder).WhereColumn("o.user_id", "=", "u.id").Where("o.status", "=", "paid")
+//  var b = new(qb.WhereBuilder).Where("u.active", "=", true).WhereNotExistsOr(
+//    new(qb.SelectBuilder).SelectRaw("1").From("orders o").Where(on))
+//  _ = b.String() // "u"."active" = $1 OR NOT EXISTS (SELECT 1 FROM "orders" AS "o" WHERE "o"."user_id" = "u"."id" AND "o"."status" = $2)
+//  _ = b.Params() // [true, "paid"]
+func (b *WhereBuilder) WhereNotExistsOr(query Builder) *WhereBuilder {
+	boolean := b.or()
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		q, params, err := s.build(query)
+		return boolean + "NOT EXISTS (" + q + ")", params, err
+	})
+	return b
+}
+
+// WhereMap adds equality expressions to the group for each key of the map in sorted order.
+// A nil value is IS NULL, a slice value is IN (...), see WhereInSlice
+//  var b = new(qb.WhereBuilder).WhereMap(map[string]interface{}{
+//    "name":       "Tom",
+//    "id":         []int{1, 2},
+//    "deleted_at": nil,
+//  })
+//  _ = b.String() // "deleted_at" IS NULL AND "id" IN ($1, $2) AND "name" = $3
+//  _ = b.Params() // [1, 2, "Tom"]
+func (b *WhereBuilder) WhereMap(m map[string]interface{}) *WhereBuilder {
+	var keys = make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var v = m[k]
+		if isNil(v) {
+			b.WhereNull(k)
+		} else if values, ok := toSlice(v); ok {
+			b.WhereIn(k, values...)
+		} else {
+			b.Where(k, "=", v)
+		}
+	}
+	return b
+}
+
 // WhereNull adds an expression to the group
 //  var b = new(qb.WhereBuilder).WhereNull("data")
 //  _ = b.String() // "data" IS NULL
 //  _ = b.Params() // []
 func (b *WhereBuilder) WhereNull(field string) *WhereBuilder {
 	boolean := b.and()
-	b.groups = append(b.groups, func() string {
-		return boolean + b.g().Wrap(field) + " IS NULL"
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		return boolean + s.wrap(field) + " IS NULL", nil, nil
 	})
 	return b
 }
@@ -196,8 +329,8 @@ func (b *WhereBuilder) WhereNull(field string) *WhereBuilder {
 //  _ = b.Params() // []
 func (b *WhereBuilder) WhereNullOr(field string) *WhereBuilder {
 	boolean := b.or()
-	b.groups = append(b.groups, func() string {
-		return boolean + b.g().Wrap(field) + " IS NULL"
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		return boolean + s.wrap(field) + " IS NULL", nil, nil
 	})
 	return b
 }
@@ -208,8 +341,8 @@ func (b *WhereBuilder) WhereNullOr(field string) *WhereBuilder {
 //  _ = b.Params() // []
 func (b *WhereBuilder) WhereNotNull(field string) *WhereBuilder {
 	boolean := b.and()
-	b.groups = append(b.groups, func() string {
-		return boolean + b.g().Wrap(field) + " IS NOT NULL"
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		return boolean + s.wrap(field) + " IS NOT NULL", nil, nil
 	})
 	return b
 }
@@ -220,12 +353,218 @@ func (b *WhereBuilder) WhereNotNull(field string) *WhereBuilder {
 //  _ = b.Params() // []
 func (b *WhereBuilder) WhereNotNullOr(field string) *WhereBuilder {
 	boolean := b.or()
-	b.groups = append(b.groups, func() string {
-		return boolean + b.g().Wrap(field) + " IS NOT NULL"
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		return boolean + s.wrap(field) + " IS NOT NULL", nil, nil
+	})
+	return b
+}
+
+// WhereBetween adds an expression to the group,
+// a nil bound is dropped, so the range is half-open, and the expression is skipped if both are nil
+//  var b = new(qb.WhereBuilder).WhereBetween("price", 100, 200).WhereBetween("year", 2000, nil)
+//  _ = b.String() // "price" BETWEEN $1 AND $2 AND "year" >= $3
+//  _ = b.Params() // [100, 200, 2000]
+func (b *WhereBuilder) WhereBetween(field string, from, to interface{}) *WhereBuilder {
+	if isNil(from) && isNil(to) {
+		return b
+	}
+	return b.whereBetween(b.and(), field, false, from, to)
+}
+
+// WhereBetweenOr adds an expression to the group,
+// a nil bound is dropped, so the range is half-open, and the expression is skipped if both are nil
+//  var b = new(qb.WhereBuilder).WhereBetweenOr("price", 100, 200).WhereBetweenOr("price", nil, 10)
+//  _ = b.String() // "price" BETWEEN $1 AND $2 OR "price" <= $3
+//  _ = b.Params() // [100, 200, 10]
+func (b *WhereBuilder) WhereBetweenOr(field string, from, to interface{}) *WhereBuilder {
+	if isNil(from) && isNil(to) {
+		return b
+	}
+	return b.whereBetween(b.or(), field, false, from, to)
+}
+
+// WhereNotBetween adds an expression to the group,
+// a nil bound is dropped, so the range is half-open, and the expression is skipped if both are nil
+//  var b = new(qb.WhereBuilder).WhereNotBetween("price", 100, 200).WhereNotBetween("year", 2000, nil)
+//  _ = b.String() // "price" NOT BETWEEN $1 AND $2 AND "year" < $3
+//  _ = b.Params() // [100, 200, 2000]
+func (b *WhereBuilder) WhereNotBetween(field string, from, to interface{}) *WhereBuilder {
+	if isNil(from) && isNil(to) {
+		return b
+	}
+	return b.whereBetween(b.and(), field, true, from, to)
+}
+
+// WhereNotBetweenOr adds an expression to the group,
+// a nil bound is dropped, so the range is half-open, and the expression is skipped if both are nil
+//  var b = new(qb.WhereBuilder).WhereNotBetweenOr("price", 100, 200).WhereNotBetweenOr("price", nil, 10)
+//  _ = b.String() // "price" NOT BETWEEN $1 AND $2 OR "price" > $3
+//  _ = b.Params() // [100, 200, 10]
+func (b *WhereBuilder) WhereNotBetweenOr(field string, from, to interface{}) *WhereBuilder {
+	if isNil(from) && isNil(to) {
+		return b
+	}
+	return b.whereBetween(b.or(), field, true, from, to)
+}
+
+// WhereContains adds an expression to the group matching a field which contains the value,
+// wildcards in the value are escaped
+//  var b = new(qb.WhereBuilder).WhereContains("name", "50%")
+//  _ = b.String() // "name" LIKE $1 ESCAPE '!'
+//  _ = b.Params() // ["%50!%%"]
+func (b *WhereBuilder) WhereContains(field, value string) *WhereBuilder {
+	return b.whereLike(b.and(), field, "%"+escapeLike(value)+"%", false)
+}
+
+// WhereContainsOr adds an expression to the group matching a field which contains the value,
+// wildcards in the value are escaped
+//  var b = new(qb.WhereBuilder).WhereContainsOr("name", "50%")
+//  _ = b.String() // "name" LIKE $1 ESCAPE '!'
+//  _ = b.Params() // ["%50!%%"]
+func (b *WhereBuilder) WhereContainsOr(field, value string) *WhereBuilder {
+	return b.whereLike(b.or(), field, "%"+escapeLike(value)+"%", false)
+}
+
+// WhereContainsFold adds an expression to the group matching a field which contains the value ignoring case,
+// wildcards in the value are escaped, grammars without ILIKE compare in lower case: LOWER(`name`) LIKE LOWER(?) ESCAPE '!'
+//  var b = new(qb.WhereBuilder).WhereContainsFold("name", "50%")
+//  _ = b.String() // "name" ILIKE $1 ESCAPE '!'
+//  _ = b.Params() // ["%50!%%"]
+func (b *WhereBuilder) WhereContainsFold(field, value string) *WhereBuilder {
+	return b.whereLike(b.and(), field, "%"+escapeLike(value)+"%", true)
+}
+
+// WhereContainsFoldOr adds an expression to the group matching a field which contains the value ignoring case,
+// wildcards in the value are escaped, grammars without ILIKE compare in lower case: LOWER(`name`) LIKE LOWER(?) ESCAPE '!'
+//  var b = new(qb.WhereBuilder).WhereContainsFoldOr("name", "50%")
+//  _ = b.String() // "name" ILIKE $1 ESCAPE '!'
+//  _ = b.Params() // ["%50!%%"]
+func (b *WhereBuilder) WhereContainsFoldOr(field, value string) *WhereBuilder {
+	return b.whereLike(b.or(), field, "%"+escapeLike(value)+"%", true)
+}
+
+// WhereStartsWith adds an expression to the group matching a field which starts with the value,
+// wildcards in the value are escaped
+//  var b = new(qb.WhereBuilder).WhereStartsWith("name", "a_b")
+//  _ = b.String() // "name" LIKE $1 ESCAPE '!'
+//  _ = b.Params() // ["a!_b%"]
+func (b *WhereBuilder) WhereStartsWith(field, value string) *WhereBuilder {
+	return b.whereLike(b.and(), field, escapeLike(value)+"%", false)
+}
+
+// WhereStartsWithOr adds an expression to the group matching a field which starts with the value,
+// wildcards in the value are escaped
+//  var b = new(qb.WhereBuilder).WhereStartsWithOr("name", "a_b")
+//  _ = b.String() // "name" LIKE $1 ESCAPE '!'
+//  _ = b.Params() // ["a!_b%"]
+func (b *WhereBuilder) WhereStartsWithOr(field, value string) *WhereBuilder {
+	return b.whereLike(b.or(), field, escapeLike(value)+"%", false)
+}
+
+// WhereStartsWithFold adds an expression to the group matching a field which starts with the value ignoring case,
+// wildcards in the value are escaped, grammars without ILIKE compare in lower case: LOWER(`name`) LIKE LOWER(?) ESCAPE '!'
+//  var b = new(qb.WhereBuilder).WhereStartsWithFold("name", "a_b")
+//  _ = b.String() // "name" ILIKE $1 ESCAPE '!'
+//  _ = b.Params() // ["a!_b%"]
+func (b *WhereBuilder) WhereStartsWithFold(field, value string) *WhereBuilder {
+	return b.whereLike(b.and(), field, escapeLike(value)+"%", true)
+}
+
+// WhereStartsWithFoldOr adds an expression to the group matching a field which starts with the value ignoring case,
+// wildcards in the value are escaped, grammars without ILIKE compare in lower case: LOWER(`name`) LIKE LOWER(?) ESCAPE '!'
+//  var b = new(qb.WhereBuilder).WhereStartsWithFoldOr("name", "a_b")
+//  _ = b.String() // "name" ILIKE $1 ESCAPE '!'
+//  _ = b.Params() // ["a!_b%"]
+func (b *WhereBuilder) WhereStartsWithFoldOr(field, value string) *WhereBuilder {
+	return b.whereLike(b.or(), field, escapeLike(value)+"%", true)
+}
+
+// WhereEndsWith adds an expression to the group matching a field which ends with the value,
+// wildcards in the value are escaped
+//  var b = new(qb.WhereBuilder).WhereEndsWith("name", ".com")
+//  _ = b.String() // "name" LIKE $1 ESCAPE '!'
+//  _ = b.Params() // ["%.com"]
+func (b *WhereBuilder) WhereEndsWith(field, value string) *WhereBuilder {
+	return b.whereLike(b.and(), field, "%"+escapeLike(value), false)
+}
+
+// WhereEndsWithOr adds an expression to the group matching a field which ends with the value,
+// wildcards in the value are escaped
+//  var b = new(qb.WhereBuilder).WhereEndsWithOr("name", ".com")
+//  _ = b.String() // "name" LIKE $1 ESCAPE '!'
+//  _ = b.Params() // ["%.com"]
+func (b *WhereBuilder) WhereEndsWithOr(field, value string) *WhereBuilder {
+	return b.whereLike(b.or(), field, "%"+escapeLike(value), false)
+}
+
+// WhereEndsWithFold adds an expression to the group matching a field which ends with the value ignoring case,
+// wildcards in the value are escaped, grammars without ILIKE compare in lower case: LOWER(`name`) LIKE LOWER(?) ESCAPE '!'
+//  var b = new(qb.WhereBuilder).WhereEndsWithFold("name", ".com")
+//  _ = b.String() // "name" ILIKE $1 ESCAPE '!'
+//  _ = b.Params() // ["%.com"]
+func (b *WhereBuilder) WhereEndsWithFold(field, value string) *WhereBuilder {
+	return b.whereLike(b.and(), field, "%"+escapeLike(value), true)
+}
+
+// WhereEndsWithFoldOr adds an expression to the group matching a field which ends with the value ignoring case,
+// wildcards in the value are escaped, grammars without ILIKE compare in lower case: LOWER(`name`) LIKE LOWER(?) ESCAPE '!'
+//  var b = new(qb.WhereBuilder).WhereEndsWithFoldOr("name", ".com")
+//  _ = b.String() // "name" ILIKE $1 ESCAPE '!'
+//  _ = b.Params() // ["%.com"]
+func (b *WhereBuilder) WhereEndsWithFoldOr(field, value string) *WhereBuilder {
+	return b.whereLike(b.or(), field, "%"+escapeLike(value), true)
+}
+
+// WhereColumn adds an expression comparing two columns to the group
+//  var b = new(qb.WhereBuilder).WhereColumn("orders.user_id", "=", "users.id")
+//  _ = b.String() // "orders"."user_id" = "users"."id"
+//  _ = b.Params() // []
+func (b *WhereBuilder) WhereColumn(left, operator, right string) *WhereBuilder {
+	boolean := b.and()
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		if err := s.operator(operator); err != nil {
+			return "", nil, err
+		}
+		return boolean + s.wrap(left) + " " + operator + " " + s.wrap(right), nil, nil
+	})
+	return b
+}
+
+// WhereColumnOr adds an expression comparing two columns to the group
+//  var b = new(qb.WhereBuilder).WhereColumnOr("a.id", "=", "b.a_id").WhereColumnOr("a.id", "=", "b.c_id")
+//  _ = b.String() // "a"."id" = "b"."a_id" OR "a"."id" = "b"."c_id"
+//  _ = b.Params() // []
+func (b *WhereBuilder) WhereColumnOr(left, operator, right string) *WhereBuilder {
+	boolean := b.or()
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		if err := s.operator(operator); err != nil {
+			return "", nil, err
+		}
+		return boolean + s.wrap(left) + " " + operator + " " + s.wrap(right), nil, nil
 	})
 	return b
 }
 
+// WhereAfter adds a keyset pagination expression to the group,
+// it selects rows following the last row of a page ordered by the columns ascending.
+// Grammars without row values comparison get an expanded expression.
+//  var b = new(qb.WhereBuilder).WhereAfter([]string{"created_at", "id"}, "2019-01-01", 10)
+//  _ = b.String() // ("created_at", "id") > ($1, $2)
+//  _ = b.Params() // ["2019-01-01", 10]
+func (b *WhereBuilder) WhereAfter(columns []string, values ...interface{}) *WhereBuilder {
+	return b.whereSeek(">", columns, values)
+}
+
+// WhereBefore adds a keyset pagination expression to the group,
+// it selects rows following the last row of a page ordered by the columns descending.
+// Grammars without row values comparison get an expanded expression.
+//  var b = new(qb.WhereBuilder).WhereBefore([]string{"created_at", "id"}, "2019-01-01", 10)
+//  _ = b.String() // ("created_at", "id") < ($1, $2)
+//  _ = b.Params() // ["2019-01-01", 10]
+func (b *WhereBuilder) WhereBefore(columns []string, values ...interface{}) *WhereBuilder {
+	return b.whereSeek("<", columns, values)
+}
+
 // WhereBuilder adds an expression to the group
 //  var g = new(qb.WhereBuilder).Where("id", "=", 1).WhereOr("id", "=", 2)
 //  var b = new(qb.WhereBuilder).Where("name", "=", "Tom").WhereBuilder(g)
@@ -233,9 +572,9 @@ func (b *WhereBuilder) WhereNotNullOr(field string) *WhereBuilder {
 //  _ = b.Params() // ["Tom", 1, 2]
 func (b *WhereBuilder) WhereBuilder(group *WhereBuilder) *WhereBuilder {
 	boolean := b.and()
-	b.params = append(b.params, group.Params()...)
-	b.groups = append(b.groups, func() string {
-		return boolean + "(" + group.Grammar(b.g()).String() + ")"
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		q, params, err := s.build(group)
+		return boolean + "(" + q + ")", params, err
 	})
 	return b
 }
@@ -247,58 +586,247 @@ func (b *WhereBuilder) WhereBuilder(group *WhereBuilder) *WhereBuilder {
 //  _ = b.Params() // ["Tom", 1, 2]
 func (b *WhereBuilder) WhereBuilderOr(group *WhereBuilder) *WhereBuilder {
 	boolean := b.or()
-	b.params = append(b.params, group.Params()...)
-	b.groups = append(b.groups, func() string {
-		return boolean + "(" + group.Grammar(b.g()).String() + ")"
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		q, params, err := s.build(group)
+		return boolean + "(" + q + ")", params, err
+	})
+	return b
+}
+
+// WhereNot adds a negated expression to the group, a group rendering nothing is skipped
+//  var g = new(qb.WhereBuilder).Where("status", "=", "banned").WhereOr("status", "=", "deleted")
+//  var b = new(qb.WhereBuilder).Where("name", "=", "Tom").WhereNot(g)
+//  _ = b.String() // "name" = $1 AND NOT ("status" = $2 OR "status" = $3)
+//  _ = b.Params() // ["Tom", "banned", "deleted"]
+func (b *WhereBuilder) WhereNot(group *WhereBuilder) *WhereBuilder {
+	boolean := b.and()
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		if group == nil {
+			return "", nil, nil
+		}
+		q, params, err := s.build(group)
+		if err != nil || len(q) == 0 {
+			return "", nil, err
+		}
+		return boolean + "NOT (" + q + ")", params, nil
+	})
+	return b
+}
+
+// WhereNotOr adds a negated expression to the group, a group rendering nothing is skipped
+//  var g = new(qb.WhereBuilder).Where("status", "=", "banned").WhereOr("status", "=", "deleted")
+//  var b = new(qb.WhereBuilder).Where("name", "=", "Tom").WhereNotOr(g)
+//  _ = b.String() // "name" = $1 OR NOT ("status" = $2 OR "status" = $3)
+//  _ = b.Params() // ["Tom", "banned", "deleted"]
+func (b *WhereBuilder) WhereNotOr(group *WhereBuilder) *WhereBuilder {
+	boolean := b.or()
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		if group == nil {
+			return "", nil, nil
+		}
+		q, params, err := s.build(group)
+		if err != nil || len(q) == 0 {
+			return "", nil, err
+		}
+		return boolean + "NOT (" + q + ")", params, nil
 	})
 	return b
 }
 
 // String implementations Stringer interface
 func (b *WhereBuilder) String() string {
-	defer b.r()
-	var s strings.Builder
-	for _, f := range b.groups {
-		s.WriteString(f())
+	s, _, err := b.Build()
+	if err != nil {
+		panic(err)
 	}
-	return s.String()
+	return s
 }
 
 // Params returns parameters for query
 func (b *WhereBuilder) Params() []interface{} {
-	return b.params
+	_, params, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// Build returns the sql expression and parameters for query
+func (b *WhereBuilder) Build() (string, []interface{}, error) {
+	return render(b, b.g())
+}
+
+func (b *WhereBuilder) build(s *state) (string, []interface{}, error) {
+	var (
+		w      strings.Builder
+		params []interface{}
+	)
+	for _, f := range b.groups {
+		q, args, err := f(s)
+		if err != nil {
+			return "", nil, err
+		}
+		if w.Len() == 0 {
+			// the first expression has no boolean operator
+			q = strings.TrimPrefix(strings.TrimPrefix(q, b.and()), b.or())
+		}
+		w.WriteString(q)
+		params = append(params, args...)
+	}
+	return w.String(), params, nil
 }
 
 // Grammar sets a Grammar
 func (b *WhereBuilder) Grammar(grammar Grammar) Builder {
 	b.grammar = grammar
-	b.regular = true
 	return b
 }
 
 func (b *WhereBuilder) g() Grammar {
 	if b.grammar == nil {
-		b.grammar = grammar()
+		return defaultGrammar()
 	}
 	return b.grammar
 }
 
-func (b *WhereBuilder) r() {
-	if !b.regular {
-		b.grammar = grammar()
+func (b *WhereBuilder) whereIn(boolean, field string, not bool, values []interface{}) *WhereBuilder {
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		if len(values) == 0 {
+			if not {
+				return boolean + "1 = 1", nil, nil
+			}
+			return boolean + "1 = 0", nil, nil
+		}
+		p, params, err := s.values(values)
+		if not {
+			return boolean + s.wrap(field) + " NOT IN (" + p + ")", params, err
+		}
+		return boolean + s.wrap(field) + " IN (" + p + ")", params, err
+	})
+	return b
+}
+
+func (b *WhereBuilder) whereBetween(boolean, field string, not bool, from, to interface{}) *WhereBuilder {
+	var (
+		operator string
+		value    interface{}
+	)
+	switch {
+	case isNil(from):
+		operator, value = " <= ", to
+		if not {
+			operator = " > "
+		}
+	case isNil(to):
+		operator, value = " >= ", from
+		if not {
+			operator = " < "
+		}
 	}
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		if len(operator) > 0 {
+			v, params, err := s.value(value)
+			return boolean + s.wrap(field) + operator + v, params, err
+		}
+		var between = " BETWEEN "
+		if not {
+			between = " NOT BETWEEN "
+		}
+		v1, params, err := s.value(from)
+		if err != nil {
+			return "", nil, err
+		}
+		v2, args, err := s.value(to)
+		return boolean + s.wrap(field) + between + v1 + " AND " + v2, append(params, args...), err
+	})
+	return b
+}
+
+func (b *WhereBuilder) whereLike(boolean, field, pattern string, fold bool) *WhereBuilder {
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		p, err := s.placeholder(1)
+		if !fold {
+			return boolean + s.wrap(field) + " LIKE " + p + " ESCAPE '!'", []interface{}{pattern}, err
+		}
+		if g, ok := s.grammar.(ILikeGrammar); ok && g.ILike() {
+			return boolean + s.wrap(field) + " ILIKE " + p + " ESCAPE '!'", []interface{}{pattern}, err
+		}
+		return boolean + "LOWER(" + s.wrap(field) + ") LIKE LOWER(" + p + ") ESCAPE '!'", []interface{}{pattern}, err
+	})
+	return b
+}
+
+func (b *WhereBuilder) whereSeek(operator string, columns []string, values []interface{}) *WhereBuilder {
+	boolean := b.and()
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		if len(columns) != len(values) || len(columns) == 0 {
+			return "", nil, ErrValuesMismatch
+		}
+		if len(columns) == 1 {
+			p, params, err := s.value(values[0])
+			return boolean + s.wrap(columns[0]) + " " + operator + " " + p, params, err
+		}
+		if g, ok := s.grammar.(RowValuesGrammar); ok && g.RowValues() {
+			var w strings.Builder
+			w.WriteString(boolean + "(")
+			for i, column := range columns {
+				if i > 0 {
+					w.WriteString(", ")
+				}
+				w.WriteString(s.wrap(column))
+			}
+			p, params, err := s.values(values)
+			w.WriteString(") " + operator + " (" + p + ")")
+			return w.String(), params, err
+		}
+		// (a > $1 OR (a = $2 AND b > $3))
+		var (
+			w      strings.Builder
+			params []interface{}
+		)
+		w.WriteString(boolean + "(")
+		for i := range columns {
+			if i > 0 {
+				w.WriteString(" OR (")
+			}
+			for j := 0; j < i; j++ {
+				p, args, err := s.value(values[j])
+				if err != nil {
+					return "", nil, err
+				}
+				w.WriteString(s.wrap(columns[j]) + " = " + p + " AND ")
+				params = append(params, args...)
+			}
+			p, args, err := s.value(values[i])
+			if err != nil {
+				return "", nil, err
+			}
+			w.WriteString(s.wrap(columns[i]) + " " + operator + " " + p)
+			params = append(params, args...)
+			if i > 0 {
+				w.WriteString(")")
+			}
+		}
+		w.WriteString(")")
+		return w.String(), params, nil
+	})
+	return b
 }
 
+// whereError adds a group which fails the build with the error
+func (b *WhereBuilder) whereError(err error) *WhereBuilder {
+	b.groups = append(b.groups, func(s *state) (string, []interface{}, error) {
+		return "", nil, err
+	})
+	return b
+}
+
+// and returns the boolean operator of an expression, build drops it from the first rendered one
 func (b *WhereBuilder) and() string {
-	if len(b.groups) == 0 {
-		return ""
-	}
 	return " AND "
 }
 
+// or returns the boolean operator of an expression, build drops it from the first rendered one
 func (b *WhereBuilder) or() string {
-	if len(b.groups) == 0 {
-		return ""
-	}
 	return " OR "
 }
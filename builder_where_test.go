@@ -1,6 +1,8 @@
 package qb
 
 import (
+	"encoding/json"
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -219,3 +221,324 @@ func TestSelectWhereBuilder(t *testing.T) {
 	assert.Equal(t, `SELECT id FROM table WHERE param = $1 AND ("status" = $2 AND "type" = $3) OR ("status" = $4 AND "type" = $5) LIMIT $6`, q.String())
 	assert.Equal(t, []interface{}{"param", "active", "a", "passive", "b", 10}, q.Params())
 }
+
+func TestWhereBuilderChanged(t *testing.T) {
+	g := new(WhereBuilder).Where("a", "=", 1)
+	b := new(WhereBuilder).WhereBuilder(g)
+	g.Where("c", "=", 2)
+
+	assert.Equal(t, `("a" = $1 AND "c" = $2)`, b.String())
+	assert.Equal(t, []interface{}{1, 2}, b.Params())
+	assert.Equal(t, []interface{}{1, 2}, b.Params())
+}
+
+func TestWhereColumn(t *testing.T) {
+	b := new(WhereBuilder).
+		WhereColumn("orders.user_id", "=", "users.id").
+		WhereColumnOr("updated_at", ">", "created_at")
+
+	assert.Equal(t, `"orders"."user_id" = "users"."id" OR "updated_at" > "created_at"`, b.String())
+	assert.Nil(t, b.Params())
+}
+
+func TestWhereColumnParams(t *testing.T) {
+	b := new(WhereBuilder).
+		Where("status", "=", "active").
+		WhereColumn("updated_at::date", ">", "created_at::date").
+		WhereColumn(`a"b.id`, "<>", "c.id").
+		Where("id", ">", 10)
+
+	assert.Equal(t, `"status" = $1 AND "updated_at"::date > "created_at"::date AND "a""b"."id" <> "c"."id" AND "id" > $2`, b.String())
+	assert.Equal(t, []interface{}{"active", 10}, b.Params())
+
+	b.Grammar(MysqlGrammar())
+	assert.Equal(t, "`status` = ? AND `updated_at::date` > `created_at::date` AND `a\"b`.`id` <> `c`.`id` AND `id` > ?", b.String())
+}
+
+func TestWhereTypecast(t *testing.T) {
+	b := new(WhereBuilder).
+		Where("created_at::date", "=", "2019-01-01").
+		Where("id::int OR 1=1 --", "=", 1)
+
+	assert.Equal(t, `"created_at"::date = $1 AND "id::int OR 1=1 --" = $2`, b.String())
+
+	q, _, err := Build(b, StrictGrammar(PgsqlGrammar(), nil))
+	assert.NoError(t, err)
+	assert.Equal(t, `"created_at"::date = $1 AND "id::int OR 1=1 --" = $2`, q)
+}
+
+func TestWhereAfter(t *testing.T) {
+	b := new(WhereBuilder).
+		Where("status", "=", "active").
+		WhereAfter([]string{"created_at", "id"}, "2019-01-01", 10)
+
+	assert.Equal(t, `"status" = $1 AND ("created_at", "id") > ($2, $3)`, b.String())
+	assert.Equal(t, []interface{}{"active", "2019-01-01", 10}, b.Params())
+
+	b.Grammar(MysqlGrammar())
+	assert.Equal(t, "`status` = ? AND (`created_at`, `id`) > (?, ?)", b.String())
+
+	b = new(WhereBuilder).WhereBefore([]string{"id"}, 10)
+	assert.Equal(t, `"id" < $1`, b.String())
+}
+
+func TestWhereAfterExpanded(t *testing.T) {
+	b := new(WhereBuilder).
+		Where("status", "=", "active").
+		WhereBefore([]string{"a", "b", "c"}, 1, 2, 3).
+		Grammar(mssqlGrammar{})
+
+	assert.Equal(t, `[status] = @p1 AND ([a] < @p2 OR ([a] = @p3 AND [b] < @p4) OR ([a] = @p5 AND [b] = @p6 AND [c] < @p7))`, b.String())
+	assert.Equal(t, []interface{}{"active", 1, 1, 2, 1, 2, 3}, b.Params())
+}
+
+func TestWhereAfterExpr(t *testing.T) {
+	b := new(WhereBuilder).
+		WhereAfter([]string{"created_at", "id"}, Raw("NOW() - INTERVAL %p", "1 day"), 10).
+		WhereBefore([]string{"price"}, Col("max_price"))
+
+	assert.Equal(t, `("created_at", "id") > (NOW() - INTERVAL $1, $2) AND "price" < "max_price"`, b.String())
+	assert.Equal(t, []interface{}{"1 day", 10}, b.Params())
+
+	b.Grammar(mssqlGrammar{})
+	assert.Equal(t, `([created_at] > NOW() - INTERVAL @p1 OR ([created_at] = NOW() - INTERVAL @p2 AND [id] > @p3)) AND [price] < [max_price]`, b.String())
+	assert.Equal(t, []interface{}{"1 day", "1 day", 10}, b.Params())
+}
+
+func TestWhereAfterError(t *testing.T) {
+	_, _, err := new(WhereBuilder).WhereAfter([]string{"a", "b"}, 1).Build()
+	assert.Equal(t, ErrValuesMismatch, err)
+}
+
+func TestWhereMap(t *testing.T) {
+	var deleted *string
+	b := new(WhereBuilder).
+		Where("status", "=", "active").
+		WhereMap(map[string]interface{}{
+			"name":       "Tom",
+			"id":         []int{1, 2},
+			"type":       []string{"a"},
+			"deleted_at": nil,
+			"updated_at": deleted,
+			"data":       []byte("{}"),
+			"meta":       json.RawMessage(`{"a":1}`),
+			"ip":         net.IPv4(127, 0, 0, 1),
+		})
+
+	assert.Equal(t, `"status" = $1 AND "data" = $2 AND "deleted_at" IS NULL AND "id" IN ($3, $4) AND "ip" = $5 AND "meta" = $6 AND "name" = $7 AND "type" IN ($8) AND "updated_at" IS NULL`, b.String())
+	assert.Equal(t, []interface{}{"active", []byte("{}"), 1, 2, net.IPv4(127, 0, 0, 1), json.RawMessage(`{"a":1}`), "Tom", "a"}, b.Params())
+}
+
+func TestWhereBetween(t *testing.T) {
+	var to *int
+	b := new(WhereBuilder).
+		WhereBetween("price", 100, 200).
+		WhereBetween("year", 2000, nil).
+		WhereBetween("mileage", nil, 5000).
+		WhereBetween("color", nil, to).
+		WhereBetweenOr("price", 10, 20)
+
+	assert.Equal(t, `"price" BETWEEN $1 AND $2 AND "year" >= $3 AND "mileage" <= $4 OR "price" BETWEEN $5 AND $6`, b.String())
+	assert.Equal(t, []interface{}{100, 200, 2000, 5000, 10, 20}, b.Params())
+
+	b = new(WhereBuilder).WhereBetween("price", nil, nil)
+	assert.Equal(t, ``, b.String())
+	assert.Empty(t, b.groups)
+
+	b = new(WhereBuilder).WhereBetweenOr("price", nil, 10).WhereBetween("year", 2000, 2010).Grammar(MysqlGrammar()).(*WhereBuilder)
+	assert.Equal(t, "`price` <= ? AND `year` BETWEEN ? AND ?", b.String())
+}
+
+func TestWhereNotBetween(t *testing.T) {
+	b := new(WhereBuilder).
+		WhereNotBetween("price", 100, 200).
+		WhereNotBetween("year", 2000, nil).
+		WhereNotBetweenOr("mileage", nil, 5000).
+		WhereNotBetweenOr("color", nil, nil)
+
+	assert.Equal(t, `"price" NOT BETWEEN $1 AND $2 AND "year" < $3 OR "mileage" > $4`, b.String())
+	assert.Equal(t, []interface{}{100, 200, 2000, 5000}, b.Params())
+}
+
+func TestWhereExists(t *testing.T) {
+	on := new(WhereBuilder).
+		WhereColumn("o.user_id", "=", "u.id").
+		Where("o.status", "=", "paid")
+	sub := new(SelectBuilder).SelectRaw("1").From("orders o").Where(on)
+
+	b := new(WhereBuilder).
+		Where("u.active", "=", true).
+		WhereExists(sub).
+		WhereNotExistsOr(Query("SELECT 1 FROM bans WHERE bans.user_id = u.id AND bans.until > %p", "2019-01-01"))
+
+	assert.Equal(t, `"u"."active" = $1 AND EXISTS (SELECT 1 FROM "orders" AS "o" WHERE "o"."user_id" = "u"."id" AND "o"."status" = $2) OR NOT EXISTS (SELECT 1 FROM bans WHERE bans.user_id = u.id AND bans.until > $3)`, b.String())
+	assert.Equal(t, []interface{}{true, "paid", "2019-01-01"}, b.Params())
+
+	b = new(WhereBuilder).
+		WhereExistsOr(sub).
+		WhereNotExists(Query("SELECT 1 FROM bans WHERE %p", 1)).
+		Grammar(MysqlGrammar()).(*WhereBuilder)
+
+	assert.Equal(t, "EXISTS (SELECT 1 FROM `orders` AS `o` WHERE `o`.`user_id` = `u`.`id` AND `o`.`status` = ?) AND NOT EXISTS (SELECT 1 FROM bans WHERE ?)", b.String())
+	assert.Equal(t, []interface{}{"paid", 1}, b.Params())
+
+	_, _, err := new(WhereBuilder).WhereExists(Query("SELECT %p")).Build()
+	assert.ErrorIs(t, err, ErrTooFewParams)
+}
+
+func TestWhereContains(t *testing.T) {
+	b := new(WhereBuilder).
+		WhereContains("name", "50%").
+		WhereStartsWith("code", "a_b").
+		WhereEndsWithOr("email", "!.com")
+
+	assert.Equal(t, `"name" LIKE $1 ESCAPE '!' AND "code" LIKE $2 ESCAPE '!' OR "email" LIKE $3 ESCAPE '!'`, b.String())
+	assert.Equal(t, []interface{}{"%50!%%", "a!_b%", "%!!.com"}, b.Params())
+
+	b = new(WhereBuilder).
+		WhereContainsOr("name", "").
+		WhereStartsWithOr("code", "x").
+		WhereEndsWith("email", "y")
+
+	assert.Equal(t, `"name" LIKE $1 ESCAPE '!' OR "code" LIKE $2 ESCAPE '!' AND "email" LIKE $3 ESCAPE '!'`, b.String())
+	assert.Equal(t, []interface{}{"%%", "x%", "%y"}, b.Params())
+}
+
+func TestWhereContainsFold(t *testing.T) {
+	b := new(WhereBuilder).
+		WhereContainsFold("name", "Tom").
+		WhereStartsWithFoldOr("code", "A_").
+		WhereEndsWithFold("email", "@Mail.com").
+		WhereContainsFoldOr("city", "%").
+		WhereStartsWithFold("zip", "1").
+		WhereEndsWithFoldOr("country", "ia")
+
+	assert.Equal(t, `"name" ILIKE $1 ESCAPE '!' OR "code" ILIKE $2 ESCAPE '!' AND "email" ILIKE $3 ESCAPE '!' OR "city" ILIKE $4 ESCAPE '!' AND "zip" ILIKE $5 ESCAPE '!' OR "country" ILIKE $6 ESCAPE '!'`, b.String())
+	assert.Equal(t, []interface{}{"%Tom%", "A!_%", "%@Mail.com", "%!%%", "1%", "%ia"}, b.Params())
+
+	for _, g := range []Grammar{MysqlGrammar(), SQLiteGrammar()} {
+		q, params, err := Build(new(WhereBuilder).Where("id", ">", 1).WhereContainsFold("name", "Tom"), g)
+		assert.NoError(t, err)
+		assert.Equal(t, "`id` > ? AND LOWER(`name`) LIKE LOWER(?) ESCAPE '!'", q)
+		assert.Equal(t, []interface{}{1, "%Tom%"}, params)
+	}
+}
+
+func TestWhereNot(t *testing.T) {
+	g := new(WhereBuilder).
+		Where("status", "=", "banned").
+		WhereOr("status", "=", "deleted")
+
+	b := new(WhereBuilder).
+		Where("name", "=", "Tom").
+		WhereNot(g).
+		WhereNotOr(new(WhereBuilder).WhereNull("email"))
+
+	assert.Equal(t, `"name" = $1 AND NOT ("status" = $2 OR "status" = $3) OR NOT ("email" IS NULL)`, b.String())
+	assert.Equal(t, []interface{}{"Tom", "banned", "deleted"}, b.Params())
+}
+
+func TestWhereNotEmpty(t *testing.T) {
+	b := new(WhereBuilder).
+		WhereNot(new(WhereBuilder)).
+		WhereNotOr(nil).
+		WhereNot(Filter(struct {
+			Name string `qb:"name"`
+		}{})).
+		Where("id", "=", 1).
+		WhereNotOr(new(WhereBuilder))
+
+	assert.Equal(t, `"id" = $1`, b.String())
+	assert.Equal(t, []interface{}{1}, b.Params())
+
+	assert.Equal(t, ``, new(WhereBuilder).WhereNot(new(WhereBuilder)).String())
+}
+
+type testIDs []int64
+
+type testUUID [16]byte
+
+func TestWhereNotChanged(t *testing.T) {
+	g := new(WhereBuilder)
+	b := new(WhereBuilder).
+		WhereNot(g).
+		WhereOr("id", "=", 1)
+
+	assert.Equal(t, `"id" = $1`, b.String())
+
+	g.Where("status", "=", "banned")
+	assert.Equal(t, `NOT ("status" = $1) OR "id" = $2`, b.String())
+	assert.Equal(t, []interface{}{"banned", 1}, b.Params())
+
+	q := new(SelectBuilder).From("users").Where(new(WhereBuilder).WhereNot(new(WhereBuilder)))
+	assert.Equal(t, `SELECT * FROM "users"`, q.String())
+
+	_, _, err := new(DeleteBuilder).From("users").Where(new(WhereBuilder).WhereNot(new(WhereBuilder))).Build()
+	assert.Equal(t, ErrNoWhere, err)
+
+	_, _, err = new(UpdateBuilder).Table("users").Set(new(SetBuilder).Set("a", 1)).Where(new(WhereBuilder).WhereNotOr(nil)).Build()
+	assert.Equal(t, ErrNoWhere, err)
+}
+
+func TestWhereInSlice(t *testing.T) {
+	b := new(WhereBuilder).
+		WhereInSlice("id", testIDs{1, 2}).
+		WhereInSlice("name", []string{"a", "b"}).
+		WhereNotInSlice("uuid", []testUUID{{1}}).
+		WhereInSliceOr("color", []int{3, 4}).
+		WhereNotInSliceOr("type", "car").
+		WhereInSlice("data", [][]byte{[]byte("x")})
+
+	assert.Equal(t, `"id" IN ($1, $2) AND "name" IN ($3, $4) AND "uuid" NOT IN ($5) OR "color" IN ($6, $7) OR "type" NOT IN ($8) AND "data" IN ($9)`, b.String())
+	assert.Equal(t, []interface{}{int64(1), int64(2), "a", "b", testUUID{1}, 3, 4, "car", []byte("x")}, b.Params())
+}
+
+func TestWhereInArray(t *testing.T) {
+	id := testUUID{1, 2}
+	b := new(WhereBuilder).
+		WhereInSlice("id", id).
+		WhereMap(map[string]interface{}{"parent_id": id}).
+		WhereBuilder(Filter(struct {
+			Owner testUUID `qb:"owner_id"`
+		}{id}))
+
+	assert.Equal(t, `"id" IN ($1) AND "parent_id" = $2 AND ("owner_id" = $3)`, b.String())
+	assert.Equal(t, []interface{}{id, id, id}, b.Params())
+
+	q := Query("SELECT * FROM users WHERE id IN (%l)", id)
+	assert.Equal(t, `SELECT * FROM users WHERE id IN ($1)`, q.String())
+	assert.Equal(t, []interface{}{id}, q.Params())
+}
+
+func TestWhereInBytes(t *testing.T) {
+	var (
+		data = json.RawMessage("{}")
+		ip   = net.ParseIP("127.0.0.1")
+	)
+	b := new(WhereBuilder).
+		WhereInSlice("data", data).
+		WhereNotInSlice("ip", []net.IP{ip}).
+		WhereBuilder(Filter(struct {
+			IP net.IP `qb:"ip"`
+		}{ip}))
+
+	assert.Equal(t, `"data" IN ($1) AND "ip" NOT IN ($2) AND ("ip" = $3)`, b.String())
+	assert.Equal(t, []interface{}{data, ip, ip}, b.Params())
+
+	q := Query("SELECT * FROM events WHERE data IN (%l) AND ip IN (%s)", data, new(ListBuilder).AppendSlice(ip))
+	assert.Equal(t, `SELECT * FROM events WHERE data IN ($1) AND ip IN ($2)`, q.String())
+	assert.Equal(t, []interface{}{data, ip}, q.Params())
+}
+
+func TestWhereInEmpty(t *testing.T) {
+	b := new(WhereBuilder).
+		Where("status", "=", "active").
+		WhereInSlice("id", []int{}).
+		WhereNotInSlice("id", testIDs(nil)).
+		WhereInOr("id").
+		WhereNotInOr("id").
+		WhereMap(map[string]interface{}{"color": []string{}})
+
+	assert.Equal(t, `"status" = $1 AND 1 = 0 AND 1 = 1 OR 1 = 0 OR 1 = 1 AND 1 = 0`, b.String())
+	assert.Equal(t, []interface{}{"active"}, b.Params())
+}
@@ -0,0 +1,156 @@
+package qb
+
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	// ErrTooFewParams is returned when a query has more verbs than parameters
+	ErrTooFewParams = errors.New("qb: too few parameters")
+
+	// ErrTooManyParams is returned when a query has more parameters than verbs
+	ErrTooManyParams = errors.New("qb: too many parameters")
+
+	// ErrUnknownVerb is returned when a query contains an unsupported verb,
+	// a percent sign followed by a letter is a verb, a literal one is written as %%
+	ErrUnknownVerb = errors.New("qb: unknown verb")
+
+	// ErrNegativePlaceholder is returned when a negative count of placeholders is requested
+	ErrNegativePlaceholder = errors.New("qb: negative placeholder count")
+
+	// ErrMissingParam is returned when a named parameter of a query is missing
+	ErrMissingParam = errors.New("qb: missing named parameter")
+
+	// ErrNamedParams is returned when named parameters are neither a map with string keys nor a struct
+	ErrNamedParams = errors.New("qb: named parameters must be a map or a struct")
+
+	// ErrEmptyList is returned when a list of values or identifiers is empty
+	ErrEmptyList = errors.New("qb: empty list")
+
+	// ErrUnknownGrammar is returned when a grammar is not registered
+	ErrUnknownGrammar = errors.New("qb: unknown grammar")
+
+	// ErrNoValues is returned when an INSERT query has no rows
+	ErrNoValues = errors.New("qb: no values")
+
+	// ErrValuesMismatch is returned when a row of an INSERT query doesn't match the columns
+	ErrValuesMismatch = errors.New("qb: values count doesn't match columns count")
+
+	// ErrNotStruct is returned when a struct or a pointer to a struct is expected
+	ErrNotStruct = errors.New("qb: value is not a struct")
+
+	// ErrFilterTag is returned when a qb tag of a filter struct is invalid
+	ErrFilterTag = errors.New("qb: invalid filter tag")
+
+	// ErrNoSet is returned when an UPDATE query has no SET expressions
+	ErrNoSet = errors.New("qb: no set expressions")
+
+	// ErrNoWhere is returned when an UPDATE or DELETE query has no WHERE expressions
+	// and all rows were not requested explicitly
+	ErrNoWhere = errors.New("qb: no where expressions")
+
+	// ErrNoJoinCondition is returned when a JOIN other than CROSS JOIN has no ON expressions
+	ErrNoJoinCondition = errors.New("qb: no join condition")
+
+	// ErrInvalidDirection is returned when an order direction is not ASC or DESC
+	ErrInvalidDirection = errors.New("qb: invalid order direction")
+
+	// ErrInvalidNulls is returned when an order of nulls is not FIRST or LAST
+	ErrInvalidNulls = errors.New("qb: invalid order of nulls")
+
+	// ErrInvalidIdentifier is returned when an identifier is rejected by a strict grammar
+	ErrInvalidIdentifier = errors.New("qb: invalid identifier")
+
+	// ErrUnknownOperator is returned when an operator isn't registered for a grammar
+	ErrUnknownOperator = errors.New("qb: unknown operator")
+
+	// ErrDeleteLimit is returned when a grammar doesn't support ORDER BY and LIMIT in DELETE queries
+	ErrDeleteLimit = errors.New("qb: grammar doesn't support ORDER BY and LIMIT in DELETE queries")
+
+	// ErrDeleteOrder is returned when a grammar doesn't support ORDER BY without LIMIT in DELETE queries
+	ErrDeleteOrder = errors.New("qb: grammar doesn't support ORDER BY without LIMIT in DELETE queries")
+)
+
+// FormatError describes an error in a query template
+type FormatError struct {
+	Query string // query template
+	Pos   int    // byte offset of the verb in the query
+	Err   error  // one of ErrTooFewParams, ErrTooManyParams, ErrUnknownVerb, ErrMissingParam, ErrEmptyList, ErrInvalidIdentifier
+}
+
+// Error implementations error interface
+func (e *FormatError) Error() string {
+	if e.Pos < 0 {
+		return e.Err.Error() + " in query " + strconv.Quote(e.Query)
+	}
+	return e.Err.Error() + " at position " + strconv.Itoa(e.Pos) + " in query " + strconv.Quote(e.Query)
+}
+
+// Unwrap returns the underlying error
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// GrammarError describes an unknown grammar name
+type GrammarError struct {
+	Name string
+}
+
+// Error implementations error interface
+func (e *GrammarError) Error() string {
+	return ErrUnknownGrammar.Error() + " '" + e.Name + "'"
+}
+
+// Unwrap returns the underlying error
+func (e *GrammarError) Unwrap() error {
+	return ErrUnknownGrammar
+}
+
+// ValuesError describes a row which doesn't match the columns
+type ValuesError struct {
+	Row     int // index of the row
+	Values  int // count of values in the row
+	Columns int // count of columns
+}
+
+// Error implementations error interface
+func (e *ValuesError) Error() string {
+	return ErrValuesMismatch.Error() + ": row " + strconv.Itoa(e.Row) +
+		" has " + strconv.Itoa(e.Values) + " values, expected " + strconv.Itoa(e.Columns)
+}
+
+// Unwrap returns the underlying error
+func (e *ValuesError) Unwrap() error {
+	return ErrValuesMismatch
+}
+
+// IdentifierError describes an identifier rejected by a strict grammar
+type IdentifierError struct {
+	Identifier string
+}
+
+// Error implementations error interface
+func (e *IdentifierError) Error() string {
+	return ErrInvalidIdentifier.Error() + " " + strconv.Quote(e.Identifier)
+}
+
+// Unwrap returns the underlying error
+func (e *IdentifierError) Unwrap() error {
+	return ErrInvalidIdentifier
+}
+
+// OperatorError describes an operator which isn't registered for a grammar
+type OperatorError struct {
+	Operator string
+}
+
+// Error implementations error interface
+func (e *OperatorError) Error() string {
+	return ErrUnknownOperator.Error() + " " + strconv.Quote(e.Operator)
+}
+
+// Unwrap returns the underlying error
+func (e *OperatorError) Unwrap() error {
+	return ErrUnknownOperator
+}
@@ -0,0 +1,49 @@
+package qb
+
+// Expr is a value rendered inline as an SQL expression instead of a placeholder
+//  var b = new(qb.SetBuilder).Set("updated_at", qb.Raw("NOW()")).Set("total", qb.Col("subtotal"))
+//  _ = b.String() // "updated_at" = NOW(), "total" = "subtotal"
+type Expr interface {
+	expr(s *state) (string, []interface{}, error)
+}
+
+// Default is the DEFAULT keyword used as a value
+//  var b = new(qb.ValuesBuilder).Values(qb.Default, "Marty")
+//  _ = b.String() // (DEFAULT, $1)
+var Default Expr = defaultExpr{}
+
+// Raw returns an expression formatted like Query
+//  var b = new(qb.WhereBuilder).Where("created_at", ">", qb.Raw("NOW() - INTERVAL %p", "1 day"))
+//  _ = b.String() // "created_at" > NOW() - INTERVAL $1
+//  _ = b.Params() // ["1 day"]
+func Raw(query string, params ...interface{}) Expr {
+	return rawExpr{&format{
+		query:  query,
+		params: params,
+	}}
+}
+
+// Col returns a column wrapped in quotes of the grammar
+//  var b = new(qb.WhereBuilder).Where("updated_at", ">", qb.Col("created_at"))
+//  _ = b.String() // "updated_at" > "created_at"
+func Col(column string) Expr {
+	return colExpr(column)
+}
+
+type (
+	rawExpr     struct{ f *format }
+	colExpr     string
+	defaultExpr struct{}
+)
+
+func (e rawExpr) expr(s *state) (string, []interface{}, error) {
+	return e.f.build(s)
+}
+
+func (e colExpr) expr(s *state) (string, []interface{}, error) {
+	return s.wrap(string(e)), nil, nil
+}
+
+func (defaultExpr) expr(s *state) (string, []interface{}, error) {
+	return "DEFAULT", nil, nil
+}
@@ -0,0 +1,80 @@
+package qb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExprWhere(t *testing.T) {
+	sub := new(SelectBuilder).SelectRaw("MAX(price)").From("cars").Where(new(WhereBuilder).Where("mark", "=", "bmw"))
+	b := new(WhereBuilder).
+		Where("status", "=", "active").
+		Where("updated_at", ">", Col("created_at")).
+		Where("created_at", ">", Raw("NOW() - INTERVAL %p", "1 day")).
+		Where("price", "=", sub).
+		WhereIn("id", 1, Raw("%p + 1", 2), 3, 4).
+		WhereBetween("year", Raw("EXTRACT(YEAR FROM NOW()) - %p", 5), 2020)
+
+	assert.Equal(t, `"status" = $1 AND "updated_at" > "created_at" AND "created_at" > NOW() - INTERVAL $2 AND "price" = (SELECT MAX(price) FROM "cars" WHERE "mark" = $3) AND "id" IN ($4, $5 + 1, $6, $7) AND "year" BETWEEN EXTRACT(YEAR FROM NOW()) - $8 AND $9`, b.String())
+	assert.Equal(t, []interface{}{"active", "1 day", "bmw", 1, 2, 3, 4, 5, 2020}, b.Params())
+}
+
+func TestExprSet(t *testing.T) {
+	b := new(SetBuilder).
+		Set("name", "Marty").
+		Set("updated_at", Raw("NOW()")).
+		Set("total", Col("subtotal")).
+		Set("version", Raw("version + %p", 1)).
+		Set("status", Default).
+		Set("email", "marty@example.com")
+	q := Query("UPDATE users SET %s WHERE id = %p", b, 10)
+
+	assert.Equal(t, `UPDATE users SET "name" = $1, "updated_at" = NOW(), "total" = "subtotal", "version" = version + $2, "status" = DEFAULT, "email" = $3 WHERE id = $4`, q.String())
+	assert.Equal(t, []interface{}{"Marty", 1, "marty@example.com", 10}, q.Params())
+}
+
+func TestExprValues(t *testing.T) {
+	b := new(ValuesBuilder).
+		Values(Default, "Marty", Query("SELECT id FROM roles WHERE name = %p", "admin")).
+		Values(Default, "Emmett", Raw("NOW()"))
+	q := Query("INSERT INTO users (id, name, role) VALUES %s RETURNING %p", b, Col("id"))
+
+	assert.Equal(t, `INSERT INTO users (id, name, role) VALUES (DEFAULT, $1, (SELECT id FROM roles WHERE name = $2)), (DEFAULT, $3, NOW()) RETURNING "id"`, q.String())
+	assert.Equal(t, []interface{}{"Marty", "admin", "Emmett"}, q.Params())
+
+	i := new(InsertBuilder).Into("users").Columns("id", "name").Values(Default, "Marty").Grammar(MysqlGrammar())
+	assert.Equal(t, "INSERT INTO `users` (`id`, `name`) VALUES (DEFAULT, ?)", i.String())
+	assert.Equal(t, []interface{}{"Marty"}, i.Params())
+}
+
+func TestExprList(t *testing.T) {
+	b := new(ListBuilder).
+		Append("one", Raw("LOWER(%p)", "TWO")).
+		Append("three")
+	q := Query("SELECT id FROM table WHERE name IN (%s) AND id = %p", b, 1)
+
+	assert.Equal(t, `SELECT id FROM table WHERE name IN ($1, LOWER($2), $3) AND id = $4`, q.String())
+	assert.Equal(t, []interface{}{"one", "TWO", "three", 1}, q.Params())
+}
+
+func TestExprQuery(t *testing.T) {
+	l := new(ListBuilder).Append(1, 2)
+	q := Query("SELECT id FROM table WHERE id IN %p AND name = %p", l, "Tom")
+
+	assert.Equal(t, `SELECT id FROM table WHERE id IN ($1, $2) AND name = $3`, q.String())
+	assert.Equal(t, []interface{}{1, 2, "Tom"}, q.Params())
+
+	_, _, err := Query("SELECT %p", Raw("%p")).Build()
+	assert.ErrorIs(t, err, ErrTooFewParams)
+}
+
+func TestExprQueryString(t *testing.T) {
+	q := Query("SELECT %s, %s FROM users WHERE %s AND id = %p", Raw("NOW()"), Col("u.name"), Raw("age > %p", 18), 1)
+
+	assert.Equal(t, `SELECT NOW(), "u"."name" FROM users WHERE age > $1 AND id = $2`, q.String())
+	assert.Equal(t, []interface{}{18, 1}, q.Params())
+
+	q = Query("SELECT %s FROM users", Default)
+	assert.Equal(t, `SELECT DEFAULT FROM users`, q.String())
+}
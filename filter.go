@@ -0,0 +1,120 @@
+package qb
+
+import (
+	"reflect"
+	"strings"
+)
+
+var filterOperators = map[string]string{
+	"eq":   "=",
+	"ne":   "<>",
+	"gt":   ">",
+	"gte":  ">=",
+	"lt":   "<",
+	"lte":  "<=",
+	"like": "LIKE",
+}
+
+// Filter returns WHERE expressions for fields of a struct with qb tags.
+// The tag has a column and an operator: eq (default), ne, gt, gte, lt, lte, like, in, nin or range.
+// Fields without the tag, nil pointers and empty values are skipped,
+// a pointer to an empty value is kept.
+// A slice value is IN (...) for eq and NOT IN (...) for ne,
+// range expects a slice of two bounds [from, to] and skips empty bounds.
+//  type CarFilter struct {
+//    Mark      string   `qb:"mark"`
+//    Color     []int    `qb:"color,op=in"`
+//    Price     []int    `qb:"price,op=range"`
+//    CreatedAt string   `qb:"created_at,op=gte"`
+//    Limit     int
+//  }
+//  var b = qb.Filter(CarFilter{Mark: "Ford", Price: []int{0, 1000}})
+//  _ = b.String() // "mark" = $1 AND "price" <= $2
+//  _ = b.Params() // ["Ford", 1000]
+func Filter(v interface{}) *WhereBuilder {
+	var b = new(WhereBuilder)
+	rv, err := structValue(v)
+	if err != nil {
+		return b.whereError(err)
+	}
+	var rt = rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		var (
+			sf       = rt.Field(i)
+			tag, ok  = sf.Tag.Lookup("qb")
+			fv       = rv.Field(i)
+			column   string
+			operator = "eq"
+		)
+		if !ok || tag == "-" || len(sf.PkgPath) > 0 {
+			continue
+		}
+		for j, opt := range strings.Split(tag, ",") {
+			switch {
+			case j == 0:
+				column = opt
+			case strings.HasPrefix(opt, "op="):
+				operator = opt[3:]
+			default:
+				return b.whereError(ErrFilterTag)
+			}
+		}
+		if len(column) == 0 {
+			return b.whereError(ErrFilterTag)
+		}
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem() // a pointer to an empty value is kept
+		} else if fv.IsZero() {
+			continue
+		}
+		if fv.Kind() == reflect.Slice && fv.Len() == 0 {
+			continue
+		}
+		var (
+			value     = fv.Interface()
+			values, s = toSlice(value)
+		)
+		switch operator {
+		case "in":
+			if !s {
+				values = []interface{}{value}
+			}
+			b.WhereIn(column, values...)
+		case "nin":
+			if !s {
+				values = []interface{}{value}
+			}
+			b.WhereNotIn(column, values...)
+		case "range":
+			if !s || len(values) > 2 {
+				return b.whereError(ErrFilterTag)
+			}
+			if len(values) > 0 && !isZero(values[0]) {
+				b.Where(column, ">=", values[0])
+			}
+			if len(values) > 1 && !isZero(values[1]) {
+				b.Where(column, "<=", values[1])
+			}
+		case "eq", "ne":
+			if s {
+				if operator == "eq" {
+					b.WhereIn(column, values...)
+				} else {
+					b.WhereNotIn(column, values...)
+				}
+				break
+			}
+			b.Where(column, filterOperators[operator], value)
+		default:
+			op, ok := filterOperators[operator]
+			if !ok {
+				return b.whereError(ErrFilterTag)
+			}
+			b.Where(column, op, value)
+		}
+	}
+	return b
+}
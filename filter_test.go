@@ -0,0 +1,75 @@
+package qb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type carFilter struct {
+	Mark      string   `qb:"mark"`
+	Model     string   `qb:"model,op=like"`
+	Color     []int    `qb:"color,op=in"`
+	Type      []string `qb:"type"`
+	Status    string   `qb:"status,op=ne"`
+	Price     []int    `qb:"price,op=range"`
+	CreatedAt []string `qb:"created_at::date,op=range"`
+	Year      *int     `qb:"year,op=gte"`
+	Owner     string   `qb:"-"`
+	Limit     int
+}
+
+func TestFilter(t *testing.T) {
+	year := 0
+	f := carFilter{
+		Mark:      "Ford",
+		Color:     []int{1, 2},
+		Type:      []string{"sedan", "coupe"},
+		Price:     []int{0, 1000},
+		CreatedAt: []string{"2019-01-01", "2019-02-01"},
+		Year:      &year,
+		Owner:     "Tom",
+		Limit:     10,
+	}
+	b := Filter(&f)
+
+	assert.Equal(t, `"mark" = $1 AND "color" IN ($2, $3) AND "type" IN ($4, $5) AND "price" <= $6 AND "created_at"::date >= $7 AND "created_at"::date <= $8 AND "year" >= $9`, b.String())
+	assert.Equal(t, []interface{}{"Ford", 1, 2, "sedan", "coupe", 1000, "2019-01-01", "2019-02-01", 0}, b.Params())
+}
+
+func TestFilterRangeNil(t *testing.T) {
+	b := Filter(struct {
+		Price []interface{} `qb:"price,op=range"`
+		Year  []interface{} `qb:"year,op=range"`
+	}{
+		Price: []interface{}{nil, 5},
+		Year:  []interface{}{2019, nil},
+	})
+
+	assert.Equal(t, `"price" <= $1 AND "year" >= $2`, b.String())
+	assert.Equal(t, []interface{}{5, 2019}, b.Params())
+}
+
+func TestFilterEmpty(t *testing.T) {
+	b := Filter(carFilter{})
+	assert.Equal(t, ``, b.String())
+
+	q := new(SelectBuilder).From("cars").Where(b)
+	assert.Equal(t, `SELECT * FROM "cars"`, q.String())
+}
+
+func TestFilterErrors(t *testing.T) {
+	_, _, err := Filter(1).Build()
+	assert.True(t, errors.Is(err, ErrNotStruct))
+
+	_, _, err = Filter(struct {
+		Price int `qb:"price,op=between"`
+	}{1}).Build()
+	assert.True(t, errors.Is(err, ErrFilterTag))
+
+	_, _, err = Filter(struct {
+		Price int `qb:"price,op=range"`
+	}{1}).Build()
+	assert.True(t, errors.Is(err, ErrFilterTag))
+}
@@ -1,6 +1,8 @@
 package qb
 
 import (
+	"strings"
+	"time"
 	"unsafe"
 )
 
@@ -10,6 +12,9 @@ var _ Grammar = (*mysqlGrammar)(nil)
 
 func init() {
 	RegisterGrammar("mysql", MysqlGrammar)
+	RegisterOperator(MysqlGrammar(),
+		"<=>", "REGEXP", "NOT REGEXP", "RLIKE", "NOT RLIKE",
+	)
 }
 
 // MysqlGrammar returns a specific grammar for mysql
@@ -17,40 +22,15 @@ func MysqlGrammar() Grammar {
 	return &mysqlGrammar{}
 }
 
-// Wrap wraps a string in quotes
+// Wrap wraps a string in backticks, embedded backticks are doubled
 func (g *mysqlGrammar) Wrap(s string) string {
-	var dot int
-	for i := 0; i < len(s); i++ {
-		if s[i] == '.' {
-			dot++
-		}
-	}
-
-	if dot == 0 {
-		return "`" + s + "`"
-	}
-
-	var (
-		n = dot*2 + 2 + len(s)
-		b = make([]byte, n)
-		w = 0
-		x = 0
-	)
-	for i := 0; i < len(s); i++ {
-		if s[i] == '.' {
-			w += copy(b[w:], "`"+s[x:i]+"`.")
-			x = i + 1
-		}
-	}
-
-	copy(b[w:], "`"+s[x:]+"`")
-	return *(*string)(unsafe.Pointer(&b))
+	return quoteIdent(s, '`', false)
 }
 
 // Placeholder returns n count placeholders
-func (g *mysqlGrammar) Placeholder(n int) string {
+func (g *mysqlGrammar) Placeholder(offset, n int) string {
 	if n < 0 {
-		panic("qb: negative Placeholder count")
+		panic(ErrNegativePlaceholder)
 	}
 	if n == 0 {
 		return ""
@@ -75,3 +55,53 @@ func (g *mysqlGrammar) Placeholder(n int) string {
 
 	return *(*string)(unsafe.Pointer(&b))
 }
+
+// DeleteLimit reports that mysql supports ORDER BY and LIMIT in DELETE queries
+func (g *mysqlGrammar) DeleteLimit() bool {
+	return true
+}
+
+// Order returns an ORDER BY expression, mysql doesn't support NULLS FIRST and NULLS LAST,
+// so they are emulated by ordering on ISNULL(column) first
+func (g *mysqlGrammar) Order(column, direction, nulls string) string {
+	switch nulls {
+	case "FIRST":
+		return "ISNULL(" + column + ") DESC, " + column + " " + direction
+	case "LAST":
+		return "ISNULL(" + column + ") ASC, " + column + " " + direction
+	}
+	return column + " " + direction
+}
+
+// Limit returns LIMIT and OFFSET expression,
+// mysql doesn't support OFFSET without LIMIT, so the maximum LIMIT is used
+func (g *mysqlGrammar) Limit(limit, offset func() string) string {
+	switch {
+	case limit != nil && offset != nil:
+		return "LIMIT " + limit() + " OFFSET " + offset()
+	case limit != nil:
+		return "LIMIT " + limit()
+	case offset != nil:
+		return "LIMIT 18446744073709551615 OFFSET " + offset()
+	}
+	return ""
+}
+
+// RowValues reports that mysql supports row values comparison
+func (g *mysqlGrammar) RowValues() bool {
+	return true
+}
+
+var mysqlReplacer = strings.NewReplacer(`\`, `\\`, "'", "''", "\x00", `\0`, "\n", `\n`, "\r", `\r`, "\x1a", `\Z`)
+
+// Literal returns a mysql literal, backslashes in strings are escaped
+// and times are formatted without a time zone
+func (g *mysqlGrammar) Literal(v interface{}) string {
+	switch x := v.(type) {
+	case string:
+		return "'" + mysqlReplacer.Replace(x) + "'"
+	case time.Time:
+		return "'" + x.Format("2006-01-02 15:04:05.999999") + "'"
+	}
+	return literal(v)
+}
@@ -17,21 +17,27 @@ func TestMySQL_Wrap(t *testing.T) {
 
 	res = MysqlGrammar().Wrap("public.tx.name")
 	assert.Equal(t, "`public`.`tx`.`name`", res)
+
+	res = MysqlGrammar().Wrap("my`name")
+	assert.Equal(t, "`my``name`", res)
+
+	res = MysqlGrammar().Wrap("tx.name` = '' OR `1")
+	assert.Equal(t, "`tx`.`name`` = '' OR ``1`", res)
 }
 
 func TestMySQL_Placeholder(t *testing.T) {
 	var res string
 
-	res = MysqlGrammar().Placeholder(0)
+	res = MysqlGrammar().Placeholder(0, 0)
 	assert.Equal(t, ``, res)
 
-	res = MysqlGrammar().Placeholder(1)
+	res = MysqlGrammar().Placeholder(0, 1)
 	assert.Equal(t, `?`, res)
 
-	res = MysqlGrammar().Placeholder(2)
+	res = MysqlGrammar().Placeholder(0, 2)
 	assert.Equal(t, `?, ?`, res)
 
-	res = MysqlGrammar().Placeholder(3)
+	res = MysqlGrammar().Placeholder(0, 3)
 	assert.Equal(t, `?, ?, ?`, res)
 }
 
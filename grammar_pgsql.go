@@ -1,18 +1,21 @@
 package qb
 
 import (
+	"encoding/hex"
 	"strconv"
 	"unsafe"
 )
 
-type pgsqlGrammar struct {
-	placeholders int
-}
+type pgsqlGrammar struct{}
 
 var _ Grammar = (*pgsqlGrammar)(nil)
 
 func init() {
 	RegisterGrammar("postgres", PgsqlGrammar)
+	RegisterOperator(PgsqlGrammar(),
+		"@>", "<@", "?", "?|", "?&", "&&",
+		"~", "~*", "!~", "!~*", "ILIKE", "NOT ILIKE", "SIMILAR TO", "NOT SIMILAR TO",
+	)
 }
 
 // PgsqlGrammar returns a specific grammar for postgresql
@@ -20,72 +23,23 @@ func PgsqlGrammar() Grammar {
 	return &pgsqlGrammar{}
 }
 
-// Wrap wraps a string in quotes
+// Wrap wraps a string in quotes, embedded quotes are doubled,
+// a typecast like ::text or ::numeric(10, 2) is kept unquoted,
+// anything else following a colon is quoted with the identifier
 func (g *pgsqlGrammar) Wrap(s string) string {
-	var (
-		dot      int
-		typecast bool
-	)
-	for i := 0; i < len(s); i++ {
-		switch s[i] {
-		case '.':
-			dot++
-		case ':':
-			typecast = true
-			i = len(s)
-			break
-		}
-	}
-
-	if dot == 0 && !typecast {
-		return `"` + s + `"`
-	}
-
-	var (
-		n = dot*2 + 2 + len(s)
-		b = make([]byte, n)
-		w = 0
-		x = 0
-		t = false
-	)
-	for i := 0; i < len(s); i++ {
-		switch s[i] {
-		case '.':
-			w += copy(b[w:], `"`+s[x:i]+`".`)
-			x = i + 1
-		case ':':
-			w += copy(b[w:], `"`+s[x:i]+`":`)
-			x = i + 1
-			t = true
-			i = len(s)
-			break
-		}
-	}
-
-	if !t {
-		copy(b[w:], `"`+s[x:]+`"`)
-	} else {
-		copy(b[w:], s[x:])
-	}
-
-	return *(*string)(unsafe.Pointer(&b))
-}
-
-// Placeholder returns n count placeholders
-func (g *pgsqlGrammar) placeholder() int {
-	g.placeholders++
-	return g.placeholders
+	return quoteIdent(s, '"', true)
 }
 
-func (g *pgsqlGrammar) Placeholder(n int) string {
+// Placeholder returns n count placeholders following offset
+func (g *pgsqlGrammar) Placeholder(offset, n int) string {
 	if n < 0 {
-		panic("qb: negative Placeholder count")
+		panic(ErrNegativePlaceholder)
 	}
 	if n == 0 {
 		return ""
 	}
 	if n == 1 {
-		return "$" + strconv.Itoa(g.placeholder())
+		return "$" + strconv.Itoa(offset+1)
 	}
 
 	var (
@@ -93,16 +47,39 @@ func (g *pgsqlGrammar) Placeholder(n int) string {
 		cap = len(sep)*(n-1) + n
 	)
 	for i := 1; i <= n; i++ {
-		cap += intWeight(g.placeholders + i)
+		cap += intWeight(offset + i)
 	}
 
 	var b = make([]byte, 0, cap)
 	b = append(b, '$')
-	b = strconv.AppendInt(b, int64(g.placeholder()), 10)
-	for i := 1; i < n; i++ {
+	b = strconv.AppendInt(b, int64(offset+1), 10)
+	for i := 2; i <= n; i++ {
 		b = append(b, ',', ' ', '$')
-		b = strconv.AppendInt(b, int64(g.placeholder()), 10)
+		b = strconv.AppendInt(b, int64(offset+i), 10)
 	}
 
 	return *(*string)(unsafe.Pointer(&b))
 }
+
+// RowValues reports that postgresql supports row values comparison
+func (g *pgsqlGrammar) RowValues() bool {
+	return true
+}
+
+// ILike reports that postgresql supports case-insensitive ILIKE
+func (g *pgsqlGrammar) ILike() bool {
+	return true
+}
+
+// Numbered reports that postgresql placeholders are numbered
+func (g *pgsqlGrammar) Numbered() bool {
+	return true
+}
+
+// Literal returns a postgresql literal, bytes are bytea in the hex format
+func (g *pgsqlGrammar) Literal(v interface{}) string {
+	if x, ok := v.([]byte); ok {
+		return `'\x` + hex.EncodeToString(x) + `'::bytea`
+	}
+	return literal(v)
+}
@@ -25,26 +25,66 @@ func TestPgSQL_Wrap(t *testing.T) {
 	assert.Equal(t, `"public"."tx"."name"::text`, res)
 
 	res = PgsqlGrammar().Wrap("tx.name::")
-	assert.Equal(t, `"tx"."name"::`, res)
+	assert.Equal(t, `"tx"."name::"`, res)
 
 	res = PgsqlGrammar().Wrap("tx.name:")
-	assert.Equal(t, `"tx"."name":`, res)
+	assert.Equal(t, `"tx"."name:"`, res)
+
+	res = PgsqlGrammar().Wrap("price::numeric(10, 2)")
+	assert.Equal(t, `"price"::numeric(10, 2)`, res)
+
+	res = PgsqlGrammar().Wrap("tags::varchar(32)[]")
+	assert.Equal(t, `"tags"::varchar(32)[]`, res)
+
+	res = PgsqlGrammar().Wrap("created_at::timestamp with time zone")
+	assert.Equal(t, `"created_at"::timestamp with time zone`, res)
+
+	res = PgsqlGrammar().Wrap("data::jsonb::text")
+	assert.Equal(t, `"data"::jsonb::text`, res)
+
+	res = PgsqlGrammar().Wrap("id::int OR 1=1 --")
+	assert.Equal(t, `"id::int OR 1=1 --"`, res)
+
+	res = PgsqlGrammar().Wrap("id::int OR true")
+	assert.Equal(t, `"id::int OR true"`, res)
+
+	res = PgsqlGrammar().Wrap(`id::text; DROP TABLE "users"`)
+	assert.Equal(t, `"id::text; DROP TABLE ""users"""`, res)
+
+	res = PgsqlGrammar().Wrap(`my"name`)
+	assert.Equal(t, `"my""name"`, res)
+
+	res = PgsqlGrammar().Wrap(`name" = '' OR "1`)
+	assert.Equal(t, `"name"" = '' OR ""1"`, res)
+
+	res = PgsqlGrammar().Wrap(`tx.my"name::text`)
+	assert.Equal(t, `"tx"."my""name"::text`, res)
 }
 
 func TestPgSQL_Placeholder(t *testing.T) {
 	var res string
 
-	res = PgsqlGrammar().Placeholder(0)
+	res = PgsqlGrammar().Placeholder(0, 0)
 	assert.Equal(t, ``, res)
 
-	res = PgsqlGrammar().Placeholder(1)
+	res = PgsqlGrammar().Placeholder(0, 1)
 	assert.Equal(t, `$1`, res)
 
-	res = PgsqlGrammar().Placeholder(2)
+	res = PgsqlGrammar().Placeholder(0, 2)
 	assert.Equal(t, `$1, $2`, res)
 
-	res = PgsqlGrammar().Placeholder(3)
+	res = PgsqlGrammar().Placeholder(0, 3)
 	assert.Equal(t, `$1, $2, $3`, res)
+
+	res = PgsqlGrammar().Placeholder(2, 1)
+	assert.Equal(t, `$3`, res)
+
+	res = PgsqlGrammar().Placeholder(8, 3)
+	assert.Equal(t, `$9, $10, $11`, res)
+
+	assert.PanicsWithValue(t, ErrNegativePlaceholder, func() {
+		PgsqlGrammar().Placeholder(0, -1)
+	})
 }
 
 func BenchmarkPgSQL_Wrap(b *testing.B) {
@@ -56,6 +96,6 @@ func BenchmarkPgSQL_Wrap(b *testing.B) {
 
 func BenchmarkPgSQL_Placeholder(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		_ = new(pgsqlGrammar).Placeholder(10)
+		_ = new(pgsqlGrammar).Placeholder(0, 10)
 	}
 }
@@ -1,15 +1,21 @@
 package qb
 
 import (
+	"time"
 	"unsafe"
 )
 
-type sqliteGrammar struct{}
+type sqliteGrammar struct {
+	deleteLimit bool
+}
 
 var _ Grammar = (*sqliteGrammar)(nil)
 
 func init() {
 	RegisterGrammar("sqlite3", SQLiteGrammar)
+	RegisterOperator(SQLiteGrammar(),
+		"GLOB", "NOT GLOB", "REGEXP", "NOT REGEXP", "MATCH",
+	)
 }
 
 // SQLiteGrammar returns a specific grammar for sqlite
@@ -17,40 +23,22 @@ func SQLiteGrammar() Grammar {
 	return &sqliteGrammar{}
 }
 
-// Wrap wraps a string in quotes
-func (g *sqliteGrammar) Wrap(s string) string {
-	var dot int
-	for i := 0; i < len(s); i++ {
-		if s[i] == '.' {
-			dot++
-		}
-	}
-
-	if dot == 0 {
-		return "`" + s + "`"
-	}
-
-	var (
-		n = dot*2 + 2 + len(s)
-		b = make([]byte, n)
-		w = 0
-		x = 0
-	)
-	for i := 0; i < len(s); i++ {
-		if s[i] == '.' {
-			w += copy(b[w:], "`"+s[x:i]+"`.")
-			x = i + 1
-		}
-	}
+// SQLiteLimitGrammar returns a specific grammar for sqlite
+// compiled with SQLITE_ENABLE_UPDATE_DELETE_LIMIT,
+// it allows ORDER BY and LIMIT in DELETE queries
+func SQLiteLimitGrammar() Grammar {
+	return &sqliteGrammar{deleteLimit: true}
+}
 
-	copy(b[w:], "`"+s[x:]+"`")
-	return *(*string)(unsafe.Pointer(&b))
+// Wrap wraps a string in backticks, embedded backticks are doubled
+func (g *sqliteGrammar) Wrap(s string) string {
+	return quoteIdent(s, '`', false)
 }
 
 // Placeholder returns n count placeholders
-func (g *sqliteGrammar) Placeholder(n int) string {
+func (g *sqliteGrammar) Placeholder(offset, n int) string {
 	if n < 0 {
-		panic("qb: negative Placeholder count")
+		panic(ErrNegativePlaceholder)
 	}
 	if n == 0 {
 		return ""
@@ -75,3 +63,47 @@ func (g *sqliteGrammar) Placeholder(n int) string {
 
 	return *(*string)(unsafe.Pointer(&b))
 }
+
+// DeleteLimit reports whether sqlite supports ORDER BY and LIMIT in DELETE queries
+func (g *sqliteGrammar) DeleteLimit() bool {
+	return g.deleteLimit
+}
+
+// DeleteOrderLimit reports that sqlite supports ORDER BY in DELETE queries only with LIMIT
+func (g *sqliteGrammar) DeleteOrderLimit() bool {
+	return true
+}
+
+// Limit returns LIMIT and OFFSET expression,
+// sqlite doesn't support OFFSET without LIMIT, so a negative LIMIT is used
+func (g *sqliteGrammar) Limit(limit, offset func() string) string {
+	switch {
+	case limit != nil && offset != nil:
+		return "LIMIT " + limit() + " OFFSET " + offset()
+	case limit != nil:
+		return "LIMIT " + limit()
+	case offset != nil:
+		return "LIMIT -1 OFFSET " + offset()
+	}
+	return ""
+}
+
+// RowValues reports that sqlite supports row values comparison
+func (g *sqliteGrammar) RowValues() bool {
+	return true
+}
+
+// Literal returns a sqlite literal, booleans are integers
+// and times are formatted with nanoseconds
+func (g *sqliteGrammar) Literal(v interface{}) string {
+	switch x := v.(type) {
+	case bool:
+		if x {
+			return "1"
+		}
+		return "0"
+	case time.Time:
+		return "'" + x.Format("2006-01-02 15:04:05.999999999-07:00") + "'"
+	}
+	return literal(v)
+}
@@ -17,21 +17,27 @@ func TestSQLite_Wrap(t *testing.T) {
 
 	res = SQLiteGrammar().Wrap("public.tx.name")
 	assert.Equal(t, "`public`.`tx`.`name`", res)
+
+	res = SQLiteGrammar().Wrap("my`name")
+	assert.Equal(t, "`my``name`", res)
+
+	res = SQLiteGrammar().Wrap("tx.name` = '' OR `1")
+	assert.Equal(t, "`tx`.`name`` = '' OR ``1`", res)
 }
 
 func TestSQLite_Placeholder(t *testing.T) {
 	var res string
 
-	res = SQLiteGrammar().Placeholder(0)
+	res = SQLiteGrammar().Placeholder(0, 0)
 	assert.Equal(t, ``, res)
 
-	res = SQLiteGrammar().Placeholder(1)
+	res = SQLiteGrammar().Placeholder(0, 1)
 	assert.Equal(t, `?`, res)
 
-	res = SQLiteGrammar().Placeholder(2)
+	res = SQLiteGrammar().Placeholder(0, 2)
 	assert.Equal(t, `?, ?`, res)
 
-	res = SQLiteGrammar().Placeholder(3)
+	res = SQLiteGrammar().Placeholder(0, 3)
 	assert.Equal(t, `?, ?, ?`, res)
 }
 
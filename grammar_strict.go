@@ -0,0 +1,39 @@
+package qb
+
+import (
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+// IdentifierPattern matches plain identifiers which may be qualified with dots
+//  users, public.users, u.created_at
+var IdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*(\.[A-Za-z_][A-Za-z0-9_$]*)*$`)
+
+type strictGrammar struct {
+	Grammar
+	pattern *regexp.Regexp
+}
+
+// StrictGrammar returns a grammar which rejects identifiers with control characters
+// and, if the pattern isn't nil, identifiers which don't match the pattern.
+// A rejected identifier fails Build with IdentifierError
+//  var g = qb.StrictGrammar(qb.PgsqlGrammar(), qb.IdentifierPattern)
+//  var w = new(qb.WhereBuilder).Where(`name" = '' OR "1`, "=", 1)
+//  _, _, err := qb.Build(w, g) // errors.Is(err, qb.ErrInvalidIdentifier)
+func StrictGrammar(g Grammar, pattern *regexp.Regexp) Grammar {
+	return &strictGrammar{Grammar: g, pattern: pattern}
+}
+
+// validate returns IdentifierError if an identifier is rejected
+func (g *strictGrammar) validate(s string) error {
+	for _, r := range s {
+		if r == utf8.RuneError || unicode.IsControl(r) {
+			return &IdentifierError{Identifier: s}
+		}
+	}
+	if g.pattern != nil && !g.pattern.MatchString(s) {
+		return &IdentifierError{Identifier: s}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package qb
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictGrammar(t *testing.T) {
+	var g = StrictGrammar(PgsqlGrammar(), IdentifierPattern)
+
+	var w = new(WhereBuilder).Where("u.name", "=", "Tom")
+	q, params, err := Build(w, g)
+	assert.NoError(t, err)
+	assert.Equal(t, `"u"."name" = $1`, q)
+	assert.Equal(t, []interface{}{"Tom"}, params)
+
+	w = new(WhereBuilder).Where("id", "=", 1).Where(`name" = '' OR "1`, "=", "Tom")
+	_, _, err = Build(w, g)
+	assert.True(t, errors.Is(err, ErrInvalidIdentifier))
+	var ie *IdentifierError
+	assert.True(t, errors.As(err, &ie))
+	assert.Equal(t, `name" = '' OR "1`, ie.Identifier)
+	assert.Equal(t, `qb: invalid identifier "name\" = '' OR \"1"`, err.Error())
+
+	var b = new(SelectBuilder).From("users u").Select("u.*", "id; DROP TABLE users")
+	b.Grammar(g)
+	_, _, err = b.Build()
+	assert.True(t, errors.Is(err, ErrInvalidIdentifier))
+	assert.Panics(t, func() { _ = b.String() })
+}
+
+func TestStrictGrammar_Control(t *testing.T) {
+	var g = StrictGrammar(MysqlGrammar(), nil)
+
+	var w = new(WhereBuilder).Where(`my"name`, "=", 1)
+	q, _, err := Build(w, g)
+	assert.NoError(t, err)
+	assert.Equal(t, "`my\"name` = ?", q)
+
+	w = new(WhereBuilder).Where("name\x00", "=", 1)
+	_, _, err = Build(w, g)
+	assert.True(t, errors.Is(err, ErrInvalidIdentifier))
+
+	w = new(WhereBuilder).Where("name\n--", "=", 1)
+	_, _, err = Build(w, g)
+	assert.True(t, errors.Is(err, ErrInvalidIdentifier))
+
+	w = new(WhereBuilder).Where("name\xff", "=", 1)
+	_, _, err = Build(w, g)
+	assert.True(t, errors.Is(err, ErrInvalidIdentifier))
+}
+
+func TestStrictGrammar_Pattern(t *testing.T) {
+	var g = StrictGrammar(PgsqlGrammar(), regexp.MustCompile(`^[a-z_]+(::[a-z]+)?$`))
+
+	var w = new(WhereBuilder).Where("name::text", "=", "Tom")
+	q, _, err := Build(w, g)
+	assert.NoError(t, err)
+	assert.Equal(t, `"name"::text = $1`, q)
+
+	w = new(WhereBuilder).Where("Name", "=", "Tom")
+	_, _, err = Build(w, g)
+	assert.True(t, errors.Is(err, ErrInvalidIdentifier))
+}
+
+func TestStrictGrammar_Capabilities(t *testing.T) {
+	var g = StrictGrammar(MysqlGrammar(), IdentifierPattern)
+
+	var b = new(SelectBuilder).From("users").Offset(10)
+	b.Grammar(g)
+	q, _, err := b.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM `users` LIMIT 18446744073709551615 OFFSET ?", q)
+
+	var d = new(DeleteBuilder).From("users").All().Limit(10)
+	d.Grammar(StrictGrammar(g, nil))
+	q, _, err = d.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM `users` LIMIT ?", q)
+}
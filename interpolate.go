@@ -0,0 +1,130 @@
+package qb
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LiteralGrammar is implemented by grammars with specific literals, see Interpolate.
+// Literal gets nil, string, []byte, bool, int64, uint64, float64 or time.Time
+type LiteralGrammar interface {
+	Grammar
+	Literal(v interface{}) string
+}
+
+// Interpolate returns the sql query string of the builder with parameters inlined
+// as literals of the builder grammar. It's for debugging and logging only,
+// never execute the result, pass the parameters to the driver instead
+//  var b = new(qb.WhereBuilder).Where("name", "=", "O'Brien").Where("active", "=", true)
+//  s, err := qb.Interpolate(b) // "name" = 'O''Brien' AND "active" = TRUE
+func Interpolate(b Builder) (string, error) {
+	var g Grammar
+	if x, ok := b.(interface{ g() Grammar }); ok {
+		g = x.g()
+	} else {
+		g = defaultGrammar()
+	}
+	_, params, err := render(b, g)
+	if err != nil {
+		return "", err
+	}
+
+	var s = newState(g)
+	s.literals = params
+	s.interpolate = true
+	q, _, err := s.build(b)
+	if err == nil {
+		err = s.err
+	}
+	if err != nil {
+		return "", err
+	}
+	return q, nil
+}
+
+// literal returns a literal of a parameter rendered so far
+func (s *state) literal(i int) (string, error) {
+	if i >= len(s.literals) {
+		return "", ErrTooFewParams
+	}
+	v, err := literalValue(s.literals[i])
+	if err != nil {
+		return "", err
+	}
+	if g, ok := s.grammar.(LiteralGrammar); ok {
+		return g.Literal(v), nil
+	}
+	return literal(v), nil
+}
+
+// literalValue converts a parameter to one of the types of LiteralGrammar
+func literalValue(v interface{}) (interface{}, error) {
+	if x, ok := v.(driver.Valuer); ok {
+		var rv = reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil, nil
+		}
+		var err error
+		if v, err = x.Value(); err != nil {
+			return nil, err
+		}
+	}
+	switch v.(type) {
+	case nil, string, []byte, bool, int64, uint64, float64, time.Time:
+		return v, nil
+	}
+	var rv = reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return literalValue(rv.Elem().Interface())
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv.Bytes(), nil
+		}
+	}
+	return fmt.Sprint(v), nil
+}
+
+// literal returns a standard sql literal
+func literal(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return "'" + strings.ReplaceAll(x, "'", "''") + "'"
+	case []byte:
+		return "X'" + hex.EncodeToString(x) + "'"
+	case bool:
+		if x {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case uint64:
+		return strconv.FormatUint(x, 10)
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64)
+	case time.Time:
+		return "'" + x.Format("2006-01-02 15:04:05.999999-07:00") + "'"
+	}
+	return literal(fmt.Sprint(v))
+}
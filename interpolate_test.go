@@ -0,0 +1,83 @@
+package qb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStatus string
+
+func TestInterpolate(t *testing.T) {
+	var (
+		now   = time.Date(2019, 1, 2, 3, 4, 5, 600000000, time.FixedZone("", 3*3600))
+		email *string
+		name  = "Tom"
+	)
+	b := new(WhereBuilder).
+		Where("name", "=", "O'Brien \\ x").
+		Where("active", "=", true).
+		Where("data", "=", []byte("hi")).
+		Where("created_at", ">", now).
+		Where("email", "IS", email).
+		Where("nick", "=", &name).
+		Where("status", "=", testStatus("new")).
+		WhereIn("id", 1, int8(-2), uint(3), 1.5).
+		Where("deleted_at", "IS", sql.NullTime{}).
+		Where("score", "=", sql.NullInt64{Int64: 7, Valid: true})
+
+	s, err := Interpolate(b)
+	assert.NoError(t, err)
+	assert.Equal(t, `"name" = 'O''Brien \ x' AND "active" = TRUE AND "data" = '\x6869'::bytea AND "created_at" > '2019-01-02 03:04:05.6+03:00' AND "email" IS NULL AND "nick" = 'Tom' AND "status" = 'new' AND "id" IN (1, -2, 3, 1.5) AND "deleted_at" IS NULL AND "score" = 7`, s)
+
+	b.Grammar(MysqlGrammar())
+	s, err = Interpolate(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "`name` = 'O''Brien \\\\ x' AND `active` = TRUE AND `data` = X'6869' AND `created_at` > '2019-01-02 03:04:05.6' AND `email` IS NULL AND `nick` = 'Tom' AND `status` = 'new' AND `id` IN (1, -2, 3, 1.5) AND `deleted_at` IS NULL AND `score` = 7", s)
+
+	b.Grammar(SQLiteGrammar())
+	s, err = Interpolate(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "`name` = 'O''Brien \\ x' AND `active` = 1 AND `data` = X'6869' AND `created_at` > '2019-01-02 03:04:05.6+03:00' AND `email` IS NULL AND `nick` = 'Tom' AND `status` = 'new' AND `id` IN (1, -2, 3, 1.5) AND `deleted_at` IS NULL AND `score` = 7", s)
+}
+
+func TestInterpolateQuery(t *testing.T) {
+	w := new(WhereBuilder).Where("name", "=", "Tom")
+	q := QueryNamed("SELECT * FROM t WHERE %(where)s AND (a = %(x)p OR b = %(x)p) AND c = %(now)p", map[string]interface{}{
+		"where": w,
+		"x":     "it's",
+		"now":   Raw("NOW()"),
+	})
+
+	s, err := Interpolate(q)
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT * FROM t WHERE "name" = 'Tom' AND (a = 'it''s' OR b = 'it''s') AND c = NOW()`, s)
+
+	s, err = Interpolate(new(SelectBuilder).From("users").Where(w).Limit(10).Grammar(MysqlGrammar()))
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM `users` WHERE `name` = 'Tom' LIMIT 10", s)
+
+	s, err = Interpolate(Query("SELECT %p", "multi\nline\x00").Grammar(MysqlGrammar()))
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT 'multi\nline\0'`, s)
+
+	s, err = Interpolate(Query("SELECT %p", 1).Grammar(mssqlGrammar{}))
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT 1`, s)
+
+	_, err = Interpolate(Query("SELECT %p"))
+	assert.ErrorIs(t, err, ErrTooFewParams)
+
+	_, err = Interpolate(Query("SELECT %p", errValuer{}))
+	assert.Equal(t, errValuerErr, err)
+}
+
+var errValuerErr = errors.New("valuer error")
+
+type errValuer struct{}
+
+func (errValuer) Value() (driver.Value, error) { return nil, errValuerErr }
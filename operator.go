@@ -0,0 +1,52 @@
+package qb
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	operatorsMu sync.RWMutex
+	operators   = map[reflect.Type]map[string]bool{} // operators of a grammar type, nil keeps standard ones
+)
+
+func init() {
+	RegisterOperator(nil,
+		"=", "<>", "!=", "<", "<=", ">", ">=",
+		"LIKE", "NOT LIKE", "IS", "IS NOT",
+		"IS DISTINCT FROM", "IS NOT DISTINCT FROM",
+	)
+}
+
+// RegisterOperator registers comparison operators for grammars of the same type as g,
+// a nil grammar registers operators for all grammars.
+// Where expressions with unregistered operators fail Build with OperatorError
+//  qb.RegisterOperator(qb.PgsqlGrammar(), "-|-")
+//  var b = new(qb.WhereBuilder).Where("during", "-|-", "[2019-01-01,2019-02-01)")
+//  _ = b.String() // "during" -|- $1
+func RegisterOperator(g Grammar, ops ...string) {
+	var t = reflect.TypeOf(g)
+	operatorsMu.Lock()
+	defer operatorsMu.Unlock()
+	if operators[t] == nil {
+		operators[t] = map[string]bool{}
+	}
+	for _, op := range ops {
+		operators[t][normalizeOperator(op)] = true
+	}
+}
+
+// validOperator reports whether an operator is registered for the grammar
+func validOperator(g Grammar, op string) bool {
+	op = normalizeOperator(op)
+	operatorsMu.RLock()
+	defer operatorsMu.RUnlock()
+	return operators[nil][op] || operators[reflect.TypeOf(g)][op]
+}
+
+// normalizeOperator converts an operator to upper case and collapses spaces
+//  "not  like" -> "NOT LIKE"
+func normalizeOperator(op string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(op), " "))
+}
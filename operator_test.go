@@ -0,0 +1,70 @@
+package qb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperator_Standard(t *testing.T) {
+	for _, g := range []Grammar{PgsqlGrammar(), MysqlGrammar(), SQLiteGrammar(), mssqlGrammar{}} {
+		for _, op := range []string{"=", "<>", "!=", "<", "<=", ">", ">=", "like", "Not  Like", "IS NOT", "IS DISTINCT FROM"} {
+			assert.True(t, validOperator(g, op), op)
+		}
+		assert.False(t, validOperator(g, "= 1 OR 1 ="))
+		assert.False(t, validOperator(g, ""))
+	}
+}
+
+func TestOperator_Dialect(t *testing.T) {
+	var b = new(WhereBuilder).Where("data", "@>", `{"a":1}`).Where("name", "ilike", "tom%")
+	q, params, err := Build(b, PgsqlGrammar())
+	assert.NoError(t, err)
+	assert.Equal(t, `"data" @> $1 AND "name" ilike $2`, q)
+	assert.Equal(t, []interface{}{`{"a":1}`, "tom%"}, params)
+
+	_, _, err = Build(b, MysqlGrammar())
+	assert.True(t, errors.Is(err, ErrUnknownOperator))
+	var oe *OperatorError
+	assert.True(t, errors.As(err, &oe))
+	assert.Equal(t, "@>", oe.Operator)
+	assert.Equal(t, `qb: unknown operator "@>"`, err.Error())
+
+	b = new(WhereBuilder).Where("name", "<=>", nil)
+	q, _, err = Build(b, MysqlGrammar())
+	assert.NoError(t, err)
+	assert.Equal(t, "`name` <=> ?", q)
+	_, _, err = Build(b, SQLiteGrammar())
+	assert.True(t, errors.Is(err, ErrUnknownOperator))
+
+	b = new(WhereBuilder).WhereOr("name", "GLOB", "T*")
+	q, _, err = Build(b, SQLiteGrammar())
+	assert.NoError(t, err)
+	assert.Equal(t, "`name` GLOB ?", q)
+	_, _, err = Build(b, PgsqlGrammar())
+	assert.True(t, errors.Is(err, ErrUnknownOperator))
+}
+
+func TestOperator_Register(t *testing.T) {
+	var b = new(WhereBuilder).WhereColumn("a.id", "=*", "b.id")
+	_, _, err := Build(b, mssqlGrammar{})
+	assert.True(t, errors.Is(err, ErrUnknownOperator))
+
+	RegisterOperator(mssqlGrammar{}, "=*")
+	q, _, err := Build(b, mssqlGrammar{})
+	assert.NoError(t, err)
+	assert.Equal(t, "[a.id] =* [b.id]", q)
+
+	_, _, err = Build(b, PgsqlGrammar())
+	assert.True(t, errors.Is(err, ErrUnknownOperator))
+}
+
+func TestOperator_Injection(t *testing.T) {
+	var b = new(WhereBuilder).Where("id", "= 1 OR 1 =", 1)
+	assert.Panics(t, func() { _ = b.String() })
+
+	var s = new(SelectBuilder).From("users").Where(b)
+	_, _, err := s.Build()
+	assert.True(t, errors.Is(err, ErrUnknownOperator))
+}
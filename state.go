@@ -0,0 +1,191 @@
+package qb
+
+import (
+	"reflect"
+	"strings"
+)
+
+// state is a render state of a query.
+// It's created for each Build call, so builders and grammars stay immutable
+// while rendering and can be rendered repeatedly and concurrently.
+type state struct {
+	grammar Grammar
+	params  int            // count of placeholders rendered so far
+	strict  *strictGrammar // validates identifiers if it's not nil
+	err     error          // first invalid identifier, it fails the build
+
+	interpolate bool          // placeholders are replaced with literals, see Interpolate
+	literals    []interface{} // parameters of the literals
+}
+
+func newState(g Grammar) *state {
+	var s = &state{grammar: g}
+	for {
+		x, ok := s.grammar.(*strictGrammar)
+		if !ok {
+			return s
+		}
+		if s.strict == nil {
+			s.strict = x
+		}
+		s.grammar = x.Grammar
+	}
+}
+
+// render renders a builder with a new state
+func render(b Builder, g Grammar) (string, []interface{}, error) {
+	var s = newState(g)
+	q, params, err := s.build(b)
+	if err == nil {
+		err = s.err
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return q, params, nil
+}
+
+// wrap wraps an identifier in quotes of the grammar,
+// an invalid identifier in strict mode is recorded to fail the build
+func (s *state) wrap(v string) string {
+	if s.strict != nil && s.err == nil {
+		s.err = s.strict.validate(v)
+	}
+	return s.grammar.Wrap(v)
+}
+
+// operator returns OperatorError if an operator isn't registered for the grammar
+func (s *state) operator(op string) error {
+	if !validOperator(s.grammar, op) {
+		return &OperatorError{Operator: op}
+	}
+	return nil
+}
+
+// placeholder returns n count placeholders following the rendered ones
+func (s *state) placeholder(n int) (string, error) {
+	if n < 0 {
+		return "", ErrNegativePlaceholder
+	}
+	if s.interpolate {
+		var w strings.Builder
+		for i := 0; i < n; i++ {
+			l, err := s.literal(s.params + i)
+			if err != nil {
+				return "", err
+			}
+			if i > 0 {
+				w.WriteString(", ")
+			}
+			w.WriteString(l)
+		}
+		s.params += n
+		return w.String(), nil
+	}
+	var p = s.grammar.Placeholder(s.params, n)
+	s.params += n
+	return p, nil
+}
+
+// value renders a value, expressions are rendered inline,
+// builders are rendered in parentheses and other values are placeholders
+func (s *state) value(v interface{}) (string, []interface{}, error) {
+	switch x := v.(type) {
+	case Expr:
+		return x.expr(s)
+	case Builder:
+		q, params, err := s.build(x)
+		if err != nil {
+			return "", nil, err
+		}
+		return "(" + q + ")", params, nil
+	}
+	p, err := s.placeholder(1)
+	if err != nil {
+		return "", nil, err
+	}
+	return p, []interface{}{v}, nil
+}
+
+// values renders a comma separated list of values,
+// consecutive plain values share one call of the grammar placeholder
+func (s *state) values(values []interface{}) (string, []interface{}, error) {
+	var (
+		w      strings.Builder
+		params = make([]interface{}, 0, len(values))
+	)
+	for i := 0; i < len(values); {
+		if i > 0 {
+			w.WriteString(", ")
+		}
+		var n = 0
+		for i+n < len(values) && !isExpr(values[i+n]) {
+			n++
+		}
+		if n > 0 {
+			p, err := s.placeholder(n)
+			if err != nil {
+				return "", nil, err
+			}
+			w.WriteString(p)
+			params = append(params, values[i:i+n]...)
+			i += n
+			continue
+		}
+		q, args, err := s.value(values[i])
+		if err != nil {
+			return "", nil, err
+		}
+		w.WriteString(q)
+		params = append(params, args...)
+		i++
+	}
+	return w.String(), params, nil
+}
+
+// isExpr reports whether a value is rendered inline
+func isExpr(v interface{}) bool {
+	switch v.(type) {
+	case Expr, Builder:
+		return true
+	}
+	return false
+}
+
+// build renders a nested builder continuing the placeholders numbering
+func (s *state) build(b Builder) (string, []interface{}, error) {
+	if x, ok := b.(builder); ok {
+		return x.build(s)
+	}
+	q, params, err := withGrammar(b, offsetGrammar{s.grammar, s.params}).Build()
+	if err != nil {
+		return "", nil, err
+	}
+	s.params += len(params)
+	return q, params, nil
+}
+
+// withGrammar sets the grammar of a builder implemented outside of the package.
+// A builder which is a pointer is copied first, so Grammar doesn't change the caller's builder
+// and the builder can be rendered concurrently, the copy is shallow
+func withGrammar(b Builder, g Grammar) Builder {
+	var v = reflect.ValueOf(b)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		var c = reflect.New(v.Elem().Type())
+		c.Elem().Set(v.Elem())
+		b = c.Interface().(Builder)
+	}
+	return b.Grammar(g)
+}
+
+// offsetGrammar shifts placeholders of a grammar,
+// it's used to render builders implemented outside of the package
+type offsetGrammar struct {
+	Grammar
+	offset int
+}
+
+// Placeholder returns n count placeholders following the offset
+func (g offsetGrammar) Placeholder(offset, n int) string {
+	return g.Grammar.Placeholder(g.offset+offset, n)
+}
@@ -0,0 +1,111 @@
+package qb
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// field is a struct field mapped to a column with the db tag
+//  struct {
+//    ID        int       `db:"id,readonly"`
+//    Name      string    `db:"name"`
+//    Email     string    `db:"email,omitempty"`
+//    Password  string    `db:"-"`
+//  }
+type field struct {
+	column    string
+	index     []int
+	omitempty bool
+	readonly  bool
+}
+
+var fieldsCache sync.Map // map[reflect.Type][]field
+
+// structValue returns a struct value of v dereferencing pointers
+func structValue(v interface{}) (reflect.Value, error) {
+	var rv = reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return rv, ErrNotStruct
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return rv, ErrNotStruct
+	}
+	return rv, nil
+}
+
+// structFields returns fields of a struct type mapped to columns,
+// fields of embedded structs are included, untagged fields are mapped to lowercase names
+func structFields(t reflect.Type) []field {
+	if f, ok := fieldsCache.Load(t); ok {
+		return f.([]field)
+	}
+	var fields = appendFields(nil, t, nil)
+	fieldsCache.Store(t, fields)
+	return fields
+}
+
+func appendFields(fields []field, t reflect.Type, index []int) []field {
+	for i := 0; i < t.NumField(); i++ {
+		var (
+			sf  = t.Field(i)
+			tag = sf.Tag.Get("db")
+			idx = append(append(make([]int, 0, len(index)+1), index...), i)
+		)
+		if tag == "-" {
+			continue
+		}
+		if sf.Anonymous && len(tag) == 0 {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = appendFields(fields, ft, idx)
+				continue
+			}
+		}
+		if len(sf.PkgPath) > 0 {
+			continue // unexported
+		}
+		var (
+			opts = strings.Split(tag, ",")
+			f    = field{column: opts[0], index: idx}
+		)
+		if len(f.column) == 0 {
+			f.column = strings.ToLower(sf.Name)
+		}
+		for _, opt := range opts[1:] {
+			switch opt {
+			case "omitempty":
+				f.omitempty = true
+			case "readonly":
+				f.readonly = true
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// value returns a value of the field, ok is false if an embedded pointer is nil
+func (f field) value(rv reflect.Value) (v reflect.Value, ok bool) {
+	for i, x := range f.index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return rv, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+// empty reports whether the value should be omitted by omitempty
+func (f field) empty(v reflect.Value) bool {
+	return f.omitempty && v.IsZero()
+}
@@ -0,0 +1,67 @@
+package qb
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	structModel struct {
+		CreatedAt time.Time `db:"created_at,omitempty"`
+		UpdatedAt time.Time `db:"updated_at,omitempty"`
+	}
+
+	structUser struct {
+		ID       int    `db:"id,readonly"`
+		Name     string `db:"name"`
+		Email    string `db:"email,omitempty"`
+		Password string `db:"-"`
+		Age      int
+		note     string
+		structModel
+	}
+)
+
+func TestSetStruct(t *testing.T) {
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	u := structUser{ID: 1, Name: "Marty", Password: "secret", Age: 17, note: "note"}
+	u.UpdatedAt = now
+
+	b := new(SetBuilder).SetStruct(&u)
+	q := Query("UPDATE users SET %s WHERE id = %p", b, u.ID)
+
+	assert.Equal(t, `UPDATE users SET "name" = $1, "age" = $2, "updated_at" = $3 WHERE id = $4`, q.String())
+	assert.Equal(t, []interface{}{"Marty", 17, now, 1}, q.Params())
+}
+
+func TestValuesStruct(t *testing.T) {
+	b := new(ValuesBuilder).ValuesStruct(
+		structUser{Name: "Marty", Email: "marty@example.com", Age: 17},
+		&structUser{Name: "Emmett", Age: 65},
+	)
+
+	assert.Equal(t, []string{"name", "email", "age"}, b.Columns())
+	assert.Equal(t, `($1, $2, $3), ($4, $5, $6)`, b.String())
+	assert.Equal(t, []interface{}{"Marty", "marty@example.com", 17, "Emmett", "", 65}, b.Params())
+
+	i := new(InsertBuilder).Into("users").Rows(b)
+	assert.Equal(t, `INSERT INTO "users" ("name", "email", "age") VALUES ($1, $2, $3), ($4, $5, $6)`, i.String())
+}
+
+func TestStructErrors(t *testing.T) {
+	_, _, err := new(SetBuilder).SetStruct(1).Build()
+	assert.True(t, errors.Is(err, ErrNotStruct))
+
+	_, _, err = new(ValuesBuilder).ValuesStruct((*structUser)(nil)).Build()
+	assert.True(t, errors.Is(err, ErrNotStruct))
+
+	_, _, err = new(InsertBuilder).Into("users").Rows(new(ValuesBuilder).ValuesStruct(5)).Build()
+	assert.True(t, errors.Is(err, ErrNotStruct))
+
+	v := new(ValuesBuilder).ValuesStruct(structUser{Name: "Marty"}, 5)
+	_, _, err = new(InsertBuilder).Into("users").Rows(v).Build()
+	assert.True(t, errors.Is(err, ErrNotStruct))
+}
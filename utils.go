@@ -1,8 +1,13 @@
 package qb
 
 import (
+	"database/sql/driver"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strconv"
+	"strings"
+	"unsafe"
 )
 
 // Convert interface to string
@@ -58,3 +63,97 @@ func intWeight(x int) int {
 	}
 	return 19
 }
+
+// isNil reports whether x is nil or a nil pointer
+func isNil(x interface{}) bool {
+	if x == nil {
+		return true
+	}
+	var v = reflect.ValueOf(x)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// isZero reports whether x is nil or a zero value
+func isZero(x interface{}) bool {
+	return isNil(x) || reflect.ValueOf(x).IsZero()
+}
+
+// toSlice converts any slice to []interface{}, byte slices like json.RawMessage or net.IP,
+// driver.Valuer and arrays like a [16]byte uuid are single values, so they are not converted
+func toSlice(x interface{}) ([]interface{}, bool) {
+	switch x := x.(type) {
+	case []interface{}:
+		return x, true
+	case driver.Valuer:
+		return nil, false
+	}
+	var v = reflect.ValueOf(x)
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	var s = make([]interface{}, v.Len())
+	for i := range s {
+		s[i] = v.Index(i).Interface()
+	}
+	return s, true
+}
+
+// sliceValues converts any slice to []interface{}, a value which isn't a slice is a single value
+func sliceValues(x interface{}) []interface{} {
+	if values, ok := toSlice(x); ok {
+		return values
+	}
+	return []interface{}{x}
+}
+
+var likeReplacer = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// escapeLike escapes LIKE wildcards with the '!' escape character
+//  escapeLike("50%_off!") // 50!%!_off!!
+func escapeLike(s string) string {
+	return likeReplacer.Replace(s)
+}
+
+// typecastPattern matches typecasts kept unquoted after an identifier
+//  ::text, ::numeric(10, 2), ::int[], ::timestamp with time zone, ::jsonb::text
+var typecastPattern = regexp.MustCompile(`^(::[A-Za-z_][A-Za-z0-9_]*(?i:( with| without) time zone| precision| varying)?(\(\d+(, ?\d+)?\))?(\[\d*\])*)+$`)
+
+// quoteIdent wraps dot separated parts of an identifier in quotes q doubling embedded quotes,
+// if typecast is true the rest of the identifier starting from a colon is kept as is
+// when it matches typecastPattern, otherwise the colon is a part of the identifier
+//  quoteIdent(`public.my"table`, '"', false) // "public"."my""table"
+//  quoteIdent("tx.name::text", '"', true)   // "tx"."name"::text
+//  quoteIdent("id::int OR 1=1", '"', true)  // "id::int OR 1=1"
+func quoteIdent(s string, q byte, typecast bool) string {
+	var n, end = 2, len(s)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '.':
+			n += 2
+		case q:
+			n++
+		case ':':
+			if typecast && typecastPattern.MatchString(s[i:]) {
+				end = i
+				i = len(s)
+			}
+		}
+	}
+
+	var b = make([]byte, 0, len(s)+n)
+	b = append(b, q)
+	for i := 0; i < end; i++ {
+		switch s[i] {
+		case '.':
+			b = append(b, q, '.', q)
+		case q:
+			b = append(b, q, q)
+		default:
+			b = append(b, s[i])
+		}
+	}
+	b = append(b, q)
+	b = append(b, s[end:]...)
+
+	return *(*string)(unsafe.Pointer(&b))
+}